@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/sts-solutions/base-code/ccredact"
 	"github.com/sts-solutions/base-code/ccvalidation"
 )
 
@@ -14,7 +15,7 @@ type DBConnection struct {
 	SSLMode   string
 	Port      int
 	UserName  string
-	Password  string
+	Password  string `redact:"true"`
 	cnnString string
 }
 
@@ -76,6 +77,12 @@ func (c *DBConnection) ConnectionString() string {
 	return c.cnnString
 }
 
+// String returns the connection string with its password redacted, so the
+// connection can be safely printed and logged
+func (c DBConnection) String() string {
+	return ccredact.String(c.cnnString)
+}
+
 func (c *DBConnection) Validate() error {
 	result := ccvalidation.Result{}
 
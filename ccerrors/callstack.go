@@ -0,0 +1,104 @@
+package ccerrors
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultStackDepth = 32
+
+var (
+	stackDepth atomic.Int32
+	stackSkip  atomic.Int32
+)
+
+func init() {
+	stackDepth.Store(defaultStackDepth)
+}
+
+// SetStackDepth sets the maximum number of frames captured by the ccerrors constructors.
+// A depth lower than 1 disables the stack capture
+func SetStackDepth(depth int) {
+	stackDepth.Store(int32(depth))
+}
+
+// SetStackSkip sets the number of extra frames skipped by the ccerrors
+// constructors, e.g. when they are always called through a helper function
+func SetStackSkip(skip int) {
+	stackSkip.Store(int32(skip))
+}
+
+// Frame is a single frame of a stack trace
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String returns the frame using the same format as emperror frames ("%+v")
+func (f Frame) String() string {
+	return fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// Stack is a stack trace captured with CaptureStack
+type Stack []uintptr
+
+// CaptureStack captures the stack trace of the caller, skipping the given number of frames
+func CaptureStack(skip int) Stack {
+	return captureStack(skip + 1)
+}
+
+// Frames returns the frames of the stack trace
+func (s Stack) Frames() []Frame {
+	if len(s) == 0 {
+		return []Frame{}
+	}
+
+	frames := make([]Frame, 0, len(s))
+	callersFrames := runtime.CallersFrames(s)
+	for {
+		frame, more := callersFrames.Next()
+		frames = append(frames, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+func captureStack(skip int) Stack {
+	depth := int(stackDepth.Load())
+	if depth < 1 {
+		return nil
+	}
+
+	pcs := make([]uintptr, depth)
+	// skip runtime.Callers and captureStack
+	n := runtime.Callers(skip+2+int(stackSkip.Load()), pcs)
+	return Stack(pcs[:n])
+}
+
+// parseFrameStrings rebuilds the frames of stack traces rendered as "file:line function"
+func parseFrameStrings(items []string) []Frame {
+	frames := make([]Frame, 0, len(items))
+	for _, item := range items {
+		location, function, _ := strings.Cut(item, " ")
+		frame := Frame{Function: function, File: location}
+		if i := strings.LastIndex(location, ":"); i >= 0 {
+			if line, err := strconv.Atoi(location[i+1:]); err == nil {
+				frame.File = location[:i]
+				frame.Line = line
+			}
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
@@ -0,0 +1,119 @@
+package ccgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testInterceptorCode = ccerrors.NewValidationErrorCode(21, "interceptor_test_invalid")
+
+type testServerStream struct {
+	grpc.ServerStream
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+	recvErr error
+}
+
+func (s *testClientStream) RecvMsg(m any) error {
+	return s.recvErr
+}
+
+func Test_UnaryServerInterceptor_HandlerError_ShouldReturnStatus(t *testing.T) {
+	// Arrange
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, ccerrors.NewDomainError(errors.New("quantity -1"), testInterceptorCode)
+	}
+
+	// Act
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.NotContains(t, st.Message(), "quantity -1")
+}
+
+func Test_UnaryServerInterceptor_NoError_ShouldReturnResponse(t *testing.T) {
+	// Arrange
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	// Act
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func Test_StreamServerInterceptor_HandlerError_ShouldReturnStatus(t *testing.T) {
+	// Arrange
+	interceptor := StreamServerInterceptor()
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return context.DeadlineExceeded
+	}
+
+	// Act
+	err := interceptor(nil, &testServerStream{}, &grpc.StreamServerInfo{}, handler)
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func Test_UnaryClientInterceptor_StatusError_ShouldRebuildDomainError(t *testing.T) {
+	// Arrange
+	interceptor := UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return ToStatus(ccerrors.NewDomainError(nil, testInterceptorCode)).Err()
+	}
+
+	// Act
+	err := interceptor(context.Background(), "/orders.Orders/Create", nil, nil, nil, invoker)
+
+	assert.ErrorIs(t, err, testInterceptorCode)
+}
+
+func Test_StreamClientInterceptor_RecvError_ShouldRebuildDomainError(t *testing.T) {
+	// Arrange
+	interceptor := StreamClientInterceptor()
+	recvErr := ToStatus(ccerrors.NewDomainError(nil, testInterceptorCode)).Err()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{recvErr: recvErr}, nil
+	}
+
+	// Act
+	cs, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/orders.Orders/Watch", streamer)
+	require.NoError(t, err)
+	recvErr = cs.RecvMsg(nil)
+
+	assert.ErrorIs(t, recvErr, testInterceptorCode)
+}
+
+func Test_StreamClientInterceptor_EOF_ShouldReturnEOF(t *testing.T) {
+	// Arrange
+	interceptor := StreamClientInterceptor()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{recvErr: io.EOF}, nil
+	}
+
+	// Act
+	cs, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/orders.Orders/Watch", streamer)
+	require.NoError(t, err)
+
+	assert.Equal(t, io.EOF, cs.RecvMsg(nil))
+}
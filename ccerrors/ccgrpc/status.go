@@ -0,0 +1,231 @@
+package ccgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorInfoDomain is the ErrorInfo domain used for DomainErrors
+	ErrorInfoDomain = "ccerrors"
+
+	codeMetadataKey    = "code"
+	nameMetadataKey    = "name"
+	detailMetadataKeys = "detail."
+
+	unknownErrorMessage = "unknown error"
+)
+
+var (
+	errorCodeStatusesMu sync.RWMutex
+	errorCodeStatuses   = map[int]codes.Code{}
+)
+
+// RegisterErrorCodeStatus overrides the gRPC code returned by ToStatus for the
+// given ErrorCode, e.g. to return codes.NotFound for a "not found" domain code
+func RegisterErrorCodeStatus(code ccerrors.ErrorCode, grpcCode codes.Code) {
+	errorCodeStatusesMu.Lock()
+	defer errorCodeStatusesMu.Unlock()
+
+	errorCodeStatuses[code.Code()] = grpcCode
+}
+
+// UnregisterErrorCodeStatus removes the gRPC code override for the given ErrorCode
+func UnregisterErrorCodeStatus(code ccerrors.ErrorCode) {
+	errorCodeStatusesMu.Lock()
+	defer errorCodeStatusesMu.Unlock()
+
+	delete(errorCodeStatuses, code.Code())
+}
+
+// CodeFromDomainError returns the gRPC code for a DomainError.
+// Registered overrides take precedence, otherwise the ErrorCode prefix is used:
+// - validation: InvalidArgument
+// - persistence: AlreadyExists on ccerrors.IsConflict, Internal
+// - external call: Unavailable, DeadlineExceeded on deadline exceeded
+// - unknown and any other prefix: Unknown
+func CodeFromDomainError(domainErr *ccerrors.DomainError) codes.Code {
+	code := domainErr.ErrorCode()
+
+	errorCodeStatusesMu.RLock()
+	grpcCode, ok := errorCodeStatuses[code.Code()]
+	errorCodeStatusesMu.RUnlock()
+	if ok {
+		return grpcCode
+	}
+
+	switch {
+	case code.IsValidation():
+		return codes.InvalidArgument
+	case code.IsPersistence():
+		if ccerrors.IsConflict(domainErr) {
+			return codes.AlreadyExists
+		}
+		return codes.Internal
+	case code.IsInvalidCode():
+		return codes.Internal
+	case code.IsExternalCall():
+		if errors.Is(domainErr, context.DeadlineExceeded) {
+			return codes.DeadlineExceeded
+		}
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// ToStatus converts an error into a gRPC status.
+// When the error chain contains a DomainError, its code, name and details are
+// sent as an ErrorInfo detail. Errors that already carry a status are returned
+// as they are, and context errors are mapped to their gRPC codes.
+// The internal error messages are never sent: the status message is the
+// registered description of the ErrorCode, or the name of the gRPC code
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	if domainErr, ok := ccerrors.AsDomainError(err); ok {
+		return domainErrorStatus(domainErr)
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.New(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.New(codes.Canceled, context.Canceled.Error())
+	}
+
+	return status.New(codes.Unknown, unknownErrorMessage)
+}
+
+// FromStatus rebuilds the DomainError sent in a gRPC status.
+// When the status has no ccerrors ErrorInfo detail the status error is returned
+func FromStatus(st *status.Status) error {
+	if st == nil {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorInfoDomain {
+			continue
+		}
+		return domainErrorFromErrorInfo(st, info)
+	}
+
+	return st.Err()
+}
+
+// FromError rebuilds the DomainError sent in the gRPC status of the error.
+// Errors without a status are returned as they are
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
+
+func domainErrorStatus(domainErr *ccerrors.DomainError) *status.Status {
+	code := domainErr.ErrorCode()
+
+	grpcCode := CodeFromDomainError(domainErr)
+	msg := grpcCode.String()
+	if entry, ok := ccerrors.LookupEntry(code.Code()); ok && entry.Description != "" {
+		msg = entry.Description
+	}
+
+	st := status.New(grpcCode, msg)
+
+	metadata := map[string]string{
+		codeMetadataKey: strconv.Itoa(code.Code()),
+		nameMetadataKey: code.Name(),
+	}
+	for k, v := range domainErr.RedactedDetail() {
+		metadata[detailMetadataKeys+k] = detailToString(v)
+	}
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   code.Error(),
+		Domain:   ErrorInfoDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func domainErrorFromErrorInfo(st *status.Status, info *errdetails.ErrorInfo) error {
+	metadata := info.GetMetadata()
+
+	numericCode, err := strconv.Atoi(metadata[codeMetadataKey])
+	if err != nil {
+		return st.Err()
+	}
+
+	code := ccerrors.FromCode(numericCode)
+	if code.Name() == "" {
+		code = code.WithName(metadata[nameMetadataKey])
+	}
+
+	var innerErr error
+	if st.Message() != code.Name() {
+		innerErr = statusError{st: st}
+	}
+
+	domainErr := ccerrors.NewDomainError(innerErr, code)
+	for k, v := range metadata {
+		if key, ok := strings.CutPrefix(k, detailMetadataKeys); ok {
+			domainErr.SetDetail(key, v)
+		}
+	}
+
+	return domainErr
+}
+
+func detailToString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+// statusError keeps the received status reachable with status.FromError
+type statusError struct {
+	st *status.Status
+}
+
+func (e statusError) Error() string {
+	return e.st.Message()
+}
+
+func (e statusError) GRPCStatus() *status.Status {
+	return e.st
+}
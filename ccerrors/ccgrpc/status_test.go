@@ -0,0 +1,90 @@
+package ccgrpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ToStatus_DomainError_ShouldMapCodeByPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{"validation", ccerrors.NewDomainError(nil, ccerrors.NewValidationErrorCode(1, "invalid")), codes.InvalidArgument},
+		{"persistence", ccerrors.NewDomainError(nil, ccerrors.NewPersistenceErrorCode(1, "db")), codes.Internal},
+		{"persistence conflict", ccerrors.NewDomainError(emperrors.Wrap(ccerrors.ErrConflict, "inserting"),
+			ccerrors.NewPersistenceErrorCode(3, "exists")), codes.AlreadyExists},
+		{"persistence duplicate key", ccerrors.NewDomainError(&pgconn.PgError{Code: "23505"},
+			ccerrors.NewPersistenceErrorCode(3, "exists")), codes.AlreadyExists},
+		{"external call", ccerrors.NewDomainError(nil, ccerrors.NewExternalCallErrorCode(1, "upstream")), codes.Unavailable},
+		{"external call timeout", ccerrors.NewDomainError(context.DeadlineExceeded,
+			ccerrors.NewExternalCallErrorCode(1, "upstream")), codes.DeadlineExceeded},
+		{"canceled", emperrors.Wrap(context.Canceled, "calling"), codes.Canceled},
+		{"plain", errors.New("boom"), codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := ToStatus(tt.err)
+
+			assert.Equal(t, tt.expected, got.Code())
+		})
+	}
+}
+
+func Test_FromError_DomainErrorStatus_ShouldRebuildDomainError(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewPersistenceErrorCode(3, "order_not_found")
+	RegisterErrorCodeStatus(code, codes.NotFound)
+	defer UnregisterErrorCodeStatus(code)
+
+	sent := ccerrors.NewDomainError(errors.New("order 7 not found"), code)
+	sent.SetDetail("order_id", "7")
+	sent.SetDetail("attempts", 2)
+
+	// Act
+	got := FromError(ToStatus(emperrors.Wrap(sent, "getting order")).Err())
+
+	domainErr, ok := ccerrors.AsDomainError(got)
+	assert.True(t, ok)
+	assert.True(t, errors.Is(got, code))
+	assert.Equal(t, "order_not_found", domainErr.ErrorCode().Name())
+	assert.Equal(t, "order_not_found: NotFound", got.Error())
+	assert.Equal(t, map[string]interface{}{"order_id": "7", "attempts": "2"}, domainErr.Detail())
+	assert.Equal(t, codes.NotFound, status.Code(got))
+}
+
+func Test_FromError_StatusWithoutErrorInfo_ShouldReturnStatusError(t *testing.T) {
+	// Arrange
+	err := status.Error(codes.PermissionDenied, "denied")
+
+	// Act
+	got := FromError(err)
+
+	_, ok := ccerrors.AsDomainError(got)
+	assert.False(t, ok)
+	assert.Equal(t, codes.PermissionDenied, status.Code(got))
+}
+
+func Test_ToStatus_DomainError_ShouldNotSendInternalMessage(t *testing.T) {
+	// Arrange
+	code := ccerrors.MustRegister(ccerrors.NewPersistenceErrorCode(4, "status_test_db_failure"), "the order could not be stored")
+	domainErr := ccerrors.NewDomainError(errors.New("insert into orders: connection refused to 10.0.0.7"), code)
+
+	// Act
+	st := ToStatus(emperrors.Wrap(domainErr, "storing order"))
+	plain := ToStatus(errors.New("reading /etc/app/config.yaml"))
+
+	assert.Equal(t, "the order could not be stored", st.Message())
+	assert.Equal(t, unknownErrorMessage, plain.Message())
+}
@@ -0,0 +1,202 @@
+package ccmessages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"emperror.dev/errors"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultMessageKey is the catalog key of the message used when no message
+// is found for the ErrorCode, its name or its category
+const DefaultMessageKey = "default"
+
+// Catalog holds the user-facing messages of the ErrorCodes per locale.
+//
+// Messages are keyed by the ErrorCode code (e.g. "911001"), its name or its
+// category, looked up in that order, and finally by DefaultMessageKey.
+// Messages are text/template templates executed with the DomainError details:
+//
+//	"921001": "Order {{.order_id}} was not found"
+type Catalog struct {
+	mu       sync.RWMutex
+	locales  []language.Tag
+	messages map[language.Tag]map[string]*template.Template
+	matcher  language.Matcher
+}
+
+// NewCatalog creates a Catalog whose fallback locale is defaultLocale
+func NewCatalog(defaultLocale string) (*Catalog, error) {
+	tag, err := language.Parse(defaultLocale)
+	if err != nil {
+		return nil, errors.WrapIff(err, "invalid default locale %q", defaultLocale)
+	}
+
+	c := &Catalog{
+		locales:  []language.Tag{tag},
+		messages: map[language.Tag]map[string]*template.Template{tag: {}},
+	}
+	c.matcher = language.NewMatcher(c.locales)
+
+	return c, nil
+}
+
+// MustNewCatalog creates a Catalog and panics if the default locale is invalid
+func MustNewCatalog(defaultLocale string) *Catalog {
+	c, err := NewCatalog(defaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// AddMessages adds the message templates of the locale to the catalog
+func (c *Catalog) AddMessages(locale string, messages map[string]string) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return errors.WrapIff(err, "invalid locale %q", locale)
+	}
+
+	templates := make(map[string]*template.Template, len(messages))
+	for key, msg := range messages {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(msg)
+		if err != nil {
+			return errors.WrapIff(err, "invalid message %q for locale %q", key, locale)
+		}
+		templates[key] = tmpl
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.messages[tag]; !ok {
+		c.messages[tag] = map[string]*template.Template{}
+		c.locales = append(c.locales, tag)
+		c.matcher = language.NewMatcher(c.locales)
+	}
+	for key, tmpl := range templates {
+		c.messages[tag][key] = tmpl
+	}
+
+	return nil
+}
+
+// LoadFS loads the message files of the directory, e.g. from an embed.FS.
+// Each file holds a flat object of messages and is named after its locale:
+// es.json, en-US.yaml, pt-BR.yml
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return errors.WrapIf(err, "reading messages directory")
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := path.Ext(entry.Name())
+		unmarshal, ok := unmarshalFuncs[ext]
+		if !ok {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return errors.WrapIff(err, "reading messages file %s", entry.Name())
+		}
+
+		messages := map[string]string{}
+		if err := unmarshal(data, &messages); err != nil {
+			return errors.WrapIff(err, "decoding messages file %s", entry.Name())
+		}
+
+		if err := c.AddMessages(strings.TrimSuffix(entry.Name(), ext), messages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var unmarshalFuncs = map[string]func([]byte, any) error{
+	".json": json.Unmarshal,
+	".yaml": yaml.Unmarshal,
+	".yml":  yaml.Unmarshal,
+}
+
+// Locales returns the locales of the catalog, the default locale first
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	locales := make([]string, 0, len(c.locales))
+	for _, tag := range c.locales {
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+// Message returns the user-facing message of the error in the locale of the
+// context (see WithLocale and WithAcceptLanguage).
+// It returns false when the catalog has no message for the error
+func (c *Catalog) Message(ctx context.Context, err error) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, index, _ := c.matcher.Match(localesFromContext(ctx)...)
+	return c.message(c.locales[index], err)
+}
+
+// MessageForLocale returns the user-facing message of the error in the locale.
+// It returns false when the catalog has no message for the error
+func (c *Catalog) MessageForLocale(locale string, err error) (string, bool) {
+	tag, _ := language.Parse(locale)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, index, _ := c.matcher.Match(tag)
+	return c.message(c.locales[index], err)
+}
+
+func (c *Catalog) message(tag language.Tag, err error) (string, bool) {
+	keys := []string{DefaultMessageKey}
+	var data map[string]any
+
+	if domainErr, ok := ccerrors.AsDomainError(err); ok {
+		code := domainErr.ErrorCode()
+		keys = []string{strconv.Itoa(code.Code()), code.Name(), code.Category(), DefaultMessageKey}
+		data = domainErr.RedactedDetail()
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	for _, locale := range []language.Tag{tag, c.locales[0]} {
+		for _, key := range keys {
+			tmpl, ok := c.messages[locale][key]
+			if !ok {
+				continue
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				continue
+			}
+			return buf.String(), true
+		}
+	}
+
+	return "", false
+}
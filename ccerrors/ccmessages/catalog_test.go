@@ -0,0 +1,59 @@
+package ccmessages
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+var errTestOrderNotFoundCode = ccerrors.NewPersistenceErrorCode(1001, "order_not_found")
+
+func newTestCatalog(t *testing.T) *Catalog {
+	catalog := MustNewCatalog("en")
+	require.NoError(t, catalog.LoadFS(os.DirFS("testdata"), "."))
+	return catalog
+}
+
+func Test_Catalog_Message_AcceptLanguage_ShouldTranslateWithDetails(t *testing.T) {
+	// Arrange
+	catalog := newTestCatalog(t)
+	err := ccerrors.NewDomainError(errors.New("select failed: no rows"), errTestOrderNotFoundCode)
+	err.SetDetail("order_id", "A-1")
+	ctx := WithAcceptLanguage(context.Background(), "es-ES,es;q=0.9,en;q=0.8")
+
+	// Act
+	got, ok := catalog.Message(ctx, err)
+
+	assert.True(t, ok)
+	assert.Equal(t, "No se encontró el pedido A-1", got)
+}
+
+func Test_Catalog_Message_MissingTranslation_ShouldFallBack(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		err      error
+		expected string
+	}{
+		{"category", "en", ccerrors.NewDomainError(nil, ccerrors.NewValidationErrorCode(1, "invalid_order")), "The request is not valid"},
+		{"default of the locale", "es", ccerrors.NewDomainError(nil, ccerrors.NewValidationErrorCode(1, "invalid_order")), "Se produjo un error inesperado"},
+		{"default locale", "fr", errors.New("internal"), "An unexpected error occurred"},
+		{"missing detail", "en", ccerrors.NewDomainError(nil, errTestOrderNotFoundCode), "An unexpected error occurred"},
+	}
+
+	catalog := newTestCatalog(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, ok := catalog.Message(WithLocale(context.Background(), tt.locale), tt.err)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
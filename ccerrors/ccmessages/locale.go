@@ -0,0 +1,60 @@
+package ccmessages
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// AcceptLanguageHeader is the HTTP header read by Middleware
+const AcceptLanguageHeader = "Accept-Language"
+
+type localesKey struct{}
+
+// WithLocale returns a context holding the preferred locales, most preferred first.
+// Invalid locales are ignored
+func WithLocale(ctx context.Context, locales ...string) context.Context {
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		if tag, err := language.Parse(locale); err == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return context.WithValue(ctx, localesKey{}, tags)
+}
+
+// WithAcceptLanguage returns a context holding the preferred locales of an
+// Accept-Language header value
+func WithAcceptLanguage(ctx context.Context, acceptLanguage string) context.Context {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	return context.WithValue(ctx, localesKey{}, tags)
+}
+
+// LocalesFromContext returns the preferred locales of the context
+func LocalesFromContext(ctx context.Context) []string {
+	tags := localesFromContext(ctx)
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+func localesFromContext(ctx context.Context) []language.Tag {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(localesKey{}).([]language.Tag)
+	return tags
+}
+
+// Middleware stores the locales of the Accept-Language header in the request context
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if acceptLanguage := r.Header.Get(AcceptLanguageHeader); acceptLanguage != "" {
+			r = r.WithContext(WithAcceptLanguage(r.Context(), acceptLanguage))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
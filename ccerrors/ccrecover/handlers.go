@@ -0,0 +1,124 @@
+package ccrecover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sts-solutions/base-code/ccerrors/ccgrpc"
+	"github.com/sts-solutions/base-code/cchttp"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccmsgqueue"
+	"google.golang.org/grpc"
+)
+
+// SafeHandler wraps the HTTP handler recovering its panics.
+// The panic is answered with the ErrorResponse returned by
+// cchttp.LocalizedFrontError, with PanicMessage when there is no message
+// catalog, unless the handler already wrote the response headers. The panic
+// value is only logged. http.ErrAbortHandler is panicked again, so net/http
+// aborts the response
+func (r *Recoverer) SafeHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writer := &trackingResponseWriter{ResponseWriter: w}
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			if value == http.ErrAbortHandler {
+				panic(value)
+			}
+
+			err := r.handle(req.Context(), value)
+			if writer.wroteHeader {
+				return
+			}
+
+			errResp := cchttp.LocalizedFrontError(req.Context(), err)
+			if cchttp.MessageCatalog() == nil {
+				errResp.Message = PanicMessage
+			}
+			w.Header().Set(cccontenttype.Key.String(), cccontenttype.ApplicationJSON.Name())
+			w.WriteHeader(errResp.HTTPCode)
+			_ = json.NewEncoder(w).Encode(errResp)
+		}()
+
+		next.ServeHTTP(writer, req)
+	})
+}
+
+// trackingResponseWriter records whether the response headers were written
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *trackingResponseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *trackingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped ResponseWriter, used by http.ResponseController
+func (w *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// SafeMessageHandler wraps the message queue handler recovering its panics.
+// The message is nacked so it can be redelivered
+func (r *Recoverer) SafeMessageHandler(
+	handler func(ctx context.Context, msg ccmsgqueue.ConsumeMessage),
+) func(ctx context.Context, msg ccmsgqueue.ConsumeMessage) {
+
+	return func(ctx context.Context, msg ccmsgqueue.ConsumeMessage) {
+		var err error
+		defer func() {
+			if err != nil {
+				_ = msg.Nack()
+			}
+		}()
+		defer r.RecoverTo(ctx, &err)
+
+		handler(ctx, msg)
+	}
+}
+
+// UnaryServerInterceptor recovers the panics of unary handlers, returning the
+// gRPC status of the panic DomainError
+func (r *Recoverer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp any, err error) {
+
+		defer func() {
+			if errors.Is(err, ErrPanicCode) {
+				err = ccgrpc.ToStatus(err).Err()
+			}
+		}()
+		defer r.RecoverTo(ctx, &err)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor recovers the panics of stream handlers, returning the
+// gRPC status of the panic DomainError
+func (r *Recoverer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+
+		defer func() {
+			if errors.Is(err, ErrPanicCode) {
+				err = ccgrpc.ToStatus(err).Err()
+			}
+		}()
+		defer r.RecoverTo(ss.Context(), &err)
+
+		return handler(srv, ss)
+	}
+}
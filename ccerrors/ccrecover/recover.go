@@ -0,0 +1,128 @@
+package ccrecover
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/cclogger"
+	"github.com/sts-solutions/base-code/ccmetrics"
+	"github.com/sts-solutions/base-code/ccmiddlewares/cccorrelation"
+)
+
+const (
+	PanicValueDetailKey    = "panic_value"
+	CorrelationIDDetailKey = "correlation_id"
+
+	// PanicMessage is the message of the responses to the recovered panics
+	PanicMessage = "internal error"
+
+	stackLogKey = "stack"
+)
+
+// ErrPanicCode is the ErrorCode of the DomainErrors created from recovered
+// panics, its value is in the range reserved for the base-code packages
+var ErrPanicCode = ccerrors.MustRegisterLibrary(
+	ccerrors.NewUnknownErrorCode(ccerrors.LibraryCodeMin, "panic_recovered"), "a panic was recovered")
+
+// Recoverer turns panics into DomainErrors, logging them and reporting them to
+// the application metrics. The logger and the metrics are optional
+type Recoverer struct {
+	logger  cclogger.Logger
+	metrics ccmetrics.ApplicationMetricsHandler
+}
+
+// New creates a Recoverer
+func New(logger cclogger.Logger, metrics ccmetrics.ApplicationMetricsHandler) *Recoverer {
+	return &Recoverer{
+		logger:  logger,
+		metrics: metrics,
+	}
+}
+
+// NewPanicError creates a DomainError with ErrPanicCode for the panic value.
+// The panic value and the correlation ID of the context are added as details
+// and, when called while panicking, the stack trace starts at the panic origin
+func NewPanicError(ctx context.Context, value any) *ccerrors.DomainError {
+	innerErr, ok := value.(error)
+	if ok {
+		innerErr = fmt.Errorf("panic: %w", innerErr)
+	} else {
+		innerErr = fmt.Errorf("panic: %v", value)
+	}
+
+	domainErr := ccerrors.NewDomainError(innerErr, ErrPanicCode).
+		WithStack(panicStack())
+	domainErr.SetDetail(PanicValueDetailKey, fmt.Sprintf("%v", value))
+	domainErr.SetDetail(CorrelationIDDetailKey, correlationID(ctx))
+
+	return domainErr
+}
+
+// RecoverTo recovers a panic and stores it as a DomainError in err.
+// It must be deferred directly:
+//
+//	defer recoverer.RecoverTo(ctx, &err)
+func (r *Recoverer) RecoverTo(ctx context.Context, err *error) {
+	if value := recover(); value != nil {
+		*err = r.handle(ctx, value)
+	}
+}
+
+// Go runs fn in a new goroutine, recovering its panics.
+// The returned channel receives the error returned by fn, or the DomainError
+// of the recovered panic, and is then closed
+func (r *Recoverer) Go(ctx context.Context, fn func(ctx context.Context) error) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		defer close(result)
+
+		var err error
+		defer func() {
+			result <- err
+		}()
+		defer r.RecoverTo(ctx, &err)
+
+		err = fn(ctx)
+	}()
+
+	return result
+}
+
+// handle creates the DomainError of the panic value, logs it and reports it
+func (r *Recoverer) handle(ctx context.Context, value any) *ccerrors.DomainError {
+	domainErr := NewPanicError(ctx, value)
+
+	if r.logger != nil {
+		logFields := cclogger.NewErrorFields(domainErr)
+		logFields = append(logFields, cclogger.LogField{Key: stackLogKey, Value: domainErr.StackFrames()})
+		r.logger.Error(ctx, "panic recovered", logFields...)
+	}
+
+	if r.metrics != nil {
+		r.metrics.PanicInc(correlationID(ctx))
+	}
+
+	return domainErr
+}
+
+func correlationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	return cccorrelation.GetCorrelationID(ctx)
+}
+
+// panicStack captures the stack trace of the caller, starting at the panic
+// origin when called while panicking
+func panicStack() ccerrors.Stack {
+	stack := ccerrors.CaptureStack(2)
+	for i, pc := range stack {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			return stack[i+1:]
+		}
+	}
+	return stack
+}
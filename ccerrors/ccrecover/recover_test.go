@@ -0,0 +1,92 @@
+package ccrecover
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccmiddlewares/cccorrelation"
+)
+
+func Test_RecoverTo_Panic_ShouldReturnPanicDomainError(t *testing.T) {
+	// Arrange
+	ctx := context.WithValue(context.Background(), cccorrelation.Key, "corr-1")
+	errBoom := errors.New("boom")
+
+	// Act
+	err := func() (err error) {
+		defer New(nil, nil).RecoverTo(ctx, &err)
+		panic(errBoom)
+	}()
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrPanicCode)
+	assert.ErrorIs(t, err, errBoom)
+
+	domainErr, ok := ccerrors.AsDomainError(err)
+	require.True(t, ok)
+	assert.Equal(t, "boom", domainErr.Detail()[PanicValueDetailKey])
+	assert.Equal(t, "corr-1", domainErr.Detail()[CorrelationIDDetailKey])
+	require.NotEmpty(t, domainErr.StackFrames())
+	assert.True(t, strings.Contains(domainErr.StackFrames()[0].Function, "Test_RecoverTo_Panic_ShouldReturnPanicDomainError"))
+}
+
+func Test_Go_Panic_ShouldSendPanicDomainError(t *testing.T) {
+	// Act
+	err := <-New(nil, nil).Go(context.Background(), func(ctx context.Context) error {
+		panic("unexpected")
+	})
+
+	assert.ErrorIs(t, err, ErrPanicCode)
+}
+
+func Test_SafeHandler_Panic_ShouldRespondInternalServerError(t *testing.T) {
+	// Arrange
+	handler := New(nil, nil).SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("unexpected s3cr3t state")
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), PanicMessage)
+	assert.NotContains(t, rec.Body.String(), "s3cr3t")
+}
+
+func Test_SafeHandler_PanicAfterWrite_ShouldKeepTheWrittenResponse(t *testing.T) {
+	// Arrange
+	handler := New(nil, nil).SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panic("unexpected state")
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
+
+func Test_SafeHandler_AbortHandler_ShouldPanicAgain(t *testing.T) {
+	// Arrange
+	handler := New(nil, nil).SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	act := func() { handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil)) }
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, act)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,331 @@
+package ccerrors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sts-solutions/base-code/ccredact"
+)
+
+// Class is the classification of an error, used to decide whether an operation
+// should be retried
+type Class int
+
+const (
+	// ClassUnknown is used for errors that cannot be classified
+	ClassUnknown Class = iota
+	// ClassTransient is used for errors expected to succeed when retried
+	ClassTransient
+	// ClassPermanent is used for errors that will fail again when retried
+	ClassPermanent
+	// ClassThrottled is used for errors caused by rate limiting, which can be
+	// retried after a delay
+	ClassThrottled
+	// ClassCancelled is used for errors caused by the cancellation of the operation
+	ClassCancelled
+)
+
+const (
+	defaultPermanentErrorMessage = "permanent error"
+	defaultThrottledErrorMessage = "throttled error"
+	defaultCancelledErrorMessage = "cancelled error"
+)
+
+var classNames = map[Class]string{
+	ClassUnknown:   "unknown",
+	ClassTransient: "transient",
+	ClassPermanent: "permanent",
+	ClassThrottled: "throttled",
+	ClassCancelled: "cancelled",
+}
+
+// String returns the name of the class
+func (c Class) String() string {
+	return classNames[c]
+}
+
+// PermanentError is an error that will fail again when retried
+type PermanentError struct {
+	message    string
+	innerError error
+	details    map[string]interface{}
+	stack      Stack
+}
+
+// NewPermanentError creates a PermanentError with the given message
+func NewPermanentError(message string, err error, details map[string]interface{}) *PermanentError {
+	return &PermanentError{
+		message:    message,
+		innerError: err,
+		details:    details,
+		stack:      captureStack(1),
+	}
+}
+
+// WrapPermanent marks the error as permanent
+func WrapPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{innerError: err, stack: captureStack(1)}
+}
+
+func (e *PermanentError) Error() string {
+	return classifiedErrorMessage(e.message, e.innerError, defaultPermanentErrorMessage)
+}
+
+// Unwrap returns the inner error
+func (e *PermanentError) Unwrap() error {
+	return e.innerError
+}
+
+// Details returns the details of the error, redacted using the ccredact Policy
+func (e *PermanentError) Details() map[string]interface{} {
+	return ccredact.Map(e.details)
+}
+
+// Class returns ClassPermanent
+func (e *PermanentError) Class() Class {
+	return ClassPermanent
+}
+
+// StackFrames returns the frames of the stack trace captured when the error was created
+func (e *PermanentError) StackFrames() []Frame {
+	return e.stack.Frames()
+}
+
+// ThrottledError is an error caused by rate limiting, which can be retried after a delay
+type ThrottledError struct {
+	message    string
+	innerError error
+	retryAfter time.Duration
+	details    map[string]interface{}
+	stack      Stack
+}
+
+// NewThrottledError creates a ThrottledError with the given message and retry delay
+func NewThrottledError(message string, err error, retryAfter time.Duration,
+	details map[string]interface{}) *ThrottledError {
+
+	return &ThrottledError{
+		message:    message,
+		innerError: err,
+		retryAfter: retryAfter,
+		details:    details,
+		stack:      captureStack(1),
+	}
+}
+
+// WrapThrottled marks the error as throttled, to be retried after the given delay
+func WrapThrottled(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &ThrottledError{innerError: err, retryAfter: retryAfter, stack: captureStack(1)}
+}
+
+func (e *ThrottledError) Error() string {
+	return classifiedErrorMessage(e.message, e.innerError, defaultThrottledErrorMessage)
+}
+
+// Unwrap returns the inner error
+func (e *ThrottledError) Unwrap() error {
+	return e.innerError
+}
+
+// Details returns the details of the error, redacted using the ccredact Policy
+func (e *ThrottledError) Details() map[string]interface{} {
+	return ccredact.Map(e.details)
+}
+
+// RetryAfter returns the delay to wait before retrying
+func (e *ThrottledError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// Class returns ClassThrottled
+func (e *ThrottledError) Class() Class {
+	return ClassThrottled
+}
+
+// StackFrames returns the frames of the stack trace captured when the error was created
+func (e *ThrottledError) StackFrames() []Frame {
+	return e.stack.Frames()
+}
+
+// CancelledError is an error caused by the cancellation of the operation
+type CancelledError struct {
+	message    string
+	innerError error
+	stack      Stack
+}
+
+// NewCancelledError creates a CancelledError with the given message
+func NewCancelledError(message string, err error) *CancelledError {
+	return &CancelledError{
+		message:    message,
+		innerError: err,
+		stack:      captureStack(1),
+	}
+}
+
+// WrapCancelled marks the error as cancelled
+func WrapCancelled(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CancelledError{innerError: err, stack: captureStack(1)}
+}
+
+func (e *CancelledError) Error() string {
+	return classifiedErrorMessage(e.message, e.innerError, defaultCancelledErrorMessage)
+}
+
+// Unwrap returns the inner error
+func (e *CancelledError) Unwrap() error {
+	return e.innerError
+}
+
+// Class returns ClassCancelled
+func (e *CancelledError) Class() Class {
+	return ClassCancelled
+}
+
+// StackFrames returns the frames of the stack trace captured when the error was created
+func (e *CancelledError) StackFrames() []Frame {
+	return e.stack.Frames()
+}
+
+// Classify returns the class of the error.
+// The nearest classified error in the chain wins, otherwise:
+// - context.Canceled is cancelled
+// - context.DeadlineExceeded, net timeouts and network errors are transient
+// - retryable pgconn errors and transient postgres SQL states are transient
+// - non retryable postgres SQL states and validation DomainErrors are permanent
+// - any other error is unknown
+func Classify(err error) Class {
+	if err == nil {
+		return ClassUnknown
+	}
+
+	var classified interface{ Class() Class }
+	if errors.As(err, &classified) {
+		return classified.Class()
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ClassCancelled
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTransient
+	}
+
+	if class, ok := classifyPgError(err); ok {
+		return class
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ClassTransient
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ClassTransient
+	}
+
+	if domainErr, ok := AsDomainError(err); ok && domainErr.ErrorCode().IsValidation() {
+		return ClassPermanent
+	}
+
+	return ClassUnknown
+}
+
+// RetryAfter returns the delay to wait before retrying a throttled error
+func RetryAfter(err error) (time.Duration, bool) {
+	var throttled interface{ RetryAfter() time.Duration }
+	if errors.As(err, &throttled) {
+		return throttled.RetryAfter(), true
+	}
+	return 0, false
+}
+
+// IsTransient returns true when the error is classified as transient
+func IsTransient(err error) bool {
+	return Classify(err) == ClassTransient
+}
+
+// IsPermanent returns true when the error is classified as permanent
+func IsPermanent(err error) bool {
+	return Classify(err) == ClassPermanent
+}
+
+// IsThrottled returns true when the error is classified as throttled
+func IsThrottled(err error) bool {
+	return Classify(err) == ClassThrottled
+}
+
+// IsCancelled returns true when the error is classified as cancelled
+func IsCancelled(err error) bool {
+	return Classify(err) == ClassCancelled
+}
+
+// IsRetryable returns true when the error is not permanent nor cancelled
+func IsRetryable(err error) bool {
+	class := Classify(err)
+	return class != ClassPermanent && class != ClassCancelled
+}
+
+// IsConflict returns true when the error chain contains ErrConflict or a
+// postgres unique or exclusion constraint violation
+func IsConflict(err error) bool {
+	if errors.Is(err, ErrConflict) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		(pgErr.Code == "23505" || // unique_violation
+			pgErr.Code == "23P01") // exclusion_violation
+}
+
+func classifyPgError(err error) (Class, bool) {
+	if pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return ClassTransient, true
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ClassUnknown, false
+	}
+
+	switch {
+	case pgErr.Code == "40001", // serialization_failure
+		pgErr.Code == "40P01",                // deadlock_detected
+		strings.HasPrefix(pgErr.Code, "08"),  // connection_exception
+		strings.HasPrefix(pgErr.Code, "53"),  // insufficient_resources
+		strings.HasPrefix(pgErr.Code, "57P"): // operator_intervention
+		return ClassTransient, true
+	case strings.HasPrefix(pgErr.Code, "22"), // data_exception
+		strings.HasPrefix(pgErr.Code, "23"), // integrity_constraint_violation
+		strings.HasPrefix(pgErr.Code, "42"): // syntax_error_or_access_rule_violation
+		return ClassPermanent, true
+	default:
+		return ClassUnknown, false
+	}
+}
+
+func classifiedErrorMessage(message string, innerError error, defaultMessage string) string {
+	if message != "" {
+		return message
+	}
+	if innerError != nil {
+		return innerError.Error()
+	}
+	return defaultMessage
+}
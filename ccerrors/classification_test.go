@@ -0,0 +1,62 @@
+package ccerrors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	emperrors "emperror.dev/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Classify_KnownErrors_ShouldReturnClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Class
+	}{
+		{"nil", nil, ClassUnknown},
+		{"plain", errors.New("boom"), ClassUnknown},
+		{"transient", NewTransientError("db down", nil, nil), ClassTransient},
+		{"wrapped permanent", emperrors.Wrap(WrapPermanent(errors.New("bad payload")), "handling"), ClassPermanent},
+		{"throttled", WrapThrottled(errors.New("429"), time.Second), ClassThrottled},
+		{"context canceled", emperrors.Wrap(context.Canceled, "calling"), ClassCancelled},
+		{"context deadline", emperrors.Wrap(context.DeadlineExceeded, "calling"), ClassTransient},
+		{"net op error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, ClassTransient},
+		{"pg deadlock", &pgconn.PgError{Code: "40P01"}, ClassTransient},
+		{"pg unique violation", &pgconn.PgError{Code: "23505"}, ClassPermanent},
+		{"validation domain error", NewDomainError(nil, NewValidationErrorCode(1, "invalid")), ClassPermanent},
+		{"nearest classification wins", WrapPermanent(WrapTransient(errors.New("x"))), ClassPermanent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := Classify(tt.err)
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_RetryAfter_ThrottledError_ShouldReturnDelay(t *testing.T) {
+	// Arrange
+	err := emperrors.Wrap(NewThrottledError("rate limited", nil, 3*time.Second, nil), "calling")
+
+	// Act
+	got, ok := RetryAfter(err)
+
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, got)
+}
+
+func Test_NewTransientError_Message_ShouldBeUsed(t *testing.T) {
+	// Act
+	err := NewTransientError("payments unavailable", errors.New("503"), nil)
+
+	assert.Equal(t, "payments unavailable", err.Error())
+	assert.Equal(t, "503", WrapTransient(errors.New("503")).Error())
+}
@@ -1,20 +1,34 @@
 package ccerrors
 
-import "runtime/debug"
+import (
+	"runtime/debug"
+
+	"github.com/sts-solutions/base-code/ccredact"
+)
+
+// StackTraceDetailKey is the DebugTrackError detail with the stack trace
+// rendered by runtime/debug, kept alongside StackFrames for its existing readers
+const StackTraceDetailKey = "stack_trace"
 
 type DebugTrackError struct {
 	message    string
 	innerError error
 	details    map[string]interface{}
+	stack      Stack
 }
 
 func NewDebugTrackError(message string, err error, details map[string]interface{}) *DebugTrackError {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+
 	returnedErr := &DebugTrackError{
 		message:    message,
 		innerError: err,
 		details:    details,
+		stack:      captureStack(1),
 	}
-	returnedErr.details["stack_trace"] = string(debug.Stack())
+	returnedErr.details[StackTraceDetailKey] = string(debug.Stack())
 	return returnedErr
 }
 
@@ -22,7 +36,17 @@ func (e *DebugTrackError) Error() string {
 	return e.message
 }
 
-func (e *DebugTrackError) Is(err error) bool {
-	_, ok := err.(*DebugTrackError)
-	return ok
+// Unwrap returns the inner error
+func (e *DebugTrackError) Unwrap() error {
+	return e.innerError
+}
+
+// Details returns the details of the error, redacted using the ccredact Policy
+func (e *DebugTrackError) Details() map[string]interface{} {
+	return ccredact.Map(e.details)
+}
+
+// StackFrames returns the frames of the stack trace captured when the error was created
+func (e *DebugTrackError) StackFrames() []Frame {
+	return e.stack.Frames()
 }
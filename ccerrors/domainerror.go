@@ -1,15 +1,19 @@
 package ccerrors
 
 import (
+	"errors"
 	"fmt"
-	"runtime"
+
+	"github.com/sts-solutions/base-code/ccredact"
 )
 
 type DomainError struct {
 	code       ErrorCode
 	detail     map[string]interface{}
 	innerError error
-	stackTrace []uintptr
+	stackTrace Stack
+	// remoteStack is the stack trace received with a decoded DomainError
+	remoteStack []string
 }
 
 type DomainErrorFunc func(*DomainError)
@@ -18,7 +22,7 @@ func NewDomainError(err error, code ErrorCode) *DomainError {
 	return &DomainError{
 		code:       code,
 		innerError: err,
-		stackTrace: callers(),
+		stackTrace: captureStack(1),
 	}
 }
 
@@ -26,24 +30,39 @@ func (e *DomainError) ErrorCode() ErrorCode {
 	return e.code
 }
 
+// StackTrace returns the stack trace captured when the DomainError was
+// created, one "file:line function" string per frame
 func (e *DomainError) StackTrace() []string {
-	result := make([]string, len(e.stackTrace))
-	for i, pc := range e.stackTrace {
-		fn := runtime.FuncForPC(pc)
-		file, line := fn.FileLine(pc)
-		frame := fmt.Sprintf("%s:%d %s", file, line, fn.Name())
-		result[i] = frame
+	if e.remoteStack != nil {
+		return e.remoteStack
+	}
+
+	frames := e.stackTrace.Frames()
+	result := make([]string, len(frames))
+	for i, frame := range frames {
+		result[i] = fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
 	}
 	return result
 }
 
-func callers() []uintptr {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(3, pcs[:])
-	return pcs[0:n]
+// WithStack replaces the stack trace captured when the DomainError was created,
+// e.g. to point at the origin of a recovered panic
+func (e *DomainError) WithStack(stack Stack) *DomainError {
+	e.stackTrace = stack
+	e.remoteStack = nil
+	return e
+}
+
+// StackFrames returns the frames of the stack trace captured when the DomainError was created
+func (e *DomainError) StackFrames() []Frame {
+	if e.remoteStack != nil {
+		return parseFrameStrings(e.remoteStack)
+	}
+	return e.stackTrace.Frames()
 }
 
+// SetDetail sets a detail of the error. The value is stored as is, it is
+// redacted when rendered, see RedactedDetail
 func (e *DomainError) SetDetail(key string, value any) {
 	if e.detail == nil {
 		e.detail = make(map[string]interface{})
@@ -57,7 +76,7 @@ func (e *DomainError) Detail() map[string]interface{} {
 			e.detail = make(map[string]interface{})
 		}
 
-		if innerDomainErr, ok := e.innerError.(*DomainError); ok {
+		if innerDomainErr, ok := AsDomainError(e.innerError); ok {
 			for k, v := range innerDomainErr.Detail() {
 				e.detail[k] = v
 			}
@@ -67,6 +86,12 @@ func (e *DomainError) Detail() map[string]interface{} {
 	return e.detail
 }
 
+// RedactedDetail returns a copy of the details redacted using the ccredact
+// Policy, so secrets never reach logs or responses
+func (e *DomainError) RedactedDetail() map[string]interface{} {
+	return ccredact.Map(e.Detail())
+}
+
 func (e *DomainError) Error() string {
 	if e.innerError != nil {
 		return fmt.Sprintf("%s: %v", e.code.Name(), e.innerError)
@@ -74,3 +99,30 @@ func (e *DomainError) Error() string {
 	}
 	return e.code.Name()
 }
+
+// Unwrap returns the inner error
+func (e *DomainError) Unwrap() error {
+	return e.innerError
+}
+
+// Is reports whether the target is an ErrorCode, or a DomainError, with the
+// same code as the DomainError, e.g. errors.Is(err, ErrOrderNotFoundCode)
+func (e *DomainError) Is(target error) bool {
+	switch t := target.(type) {
+	case ErrorCode:
+		return e.code.Code() == t.Code()
+	case *DomainError:
+		return t != nil && e.code.Code() == t.code.Code()
+	default:
+		return false
+	}
+}
+
+// AsDomainError returns the nearest DomainError in the error chain
+func AsDomainError(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
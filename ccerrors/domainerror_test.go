@@ -0,0 +1,150 @@
+package ccerrors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestOrderNotFoundCode = NewPersistenceErrorCode(1, "order_not_found")
+
+func Test_DomainError_IsErrorCode_ShouldMatchByCode(t *testing.T) {
+	// Arrange
+	err := emperrors.Wrap(NewDomainError(nil, errTestOrderNotFoundCode), "getting order")
+
+	// Act & Assert
+	assert.True(t, errors.Is(err, errTestOrderNotFoundCode))
+	assert.True(t, errors.Is(err, NewDomainError(nil, errTestOrderNotFoundCode)))
+	assert.False(t, errors.Is(err, NewPersistenceErrorCode(2, "order_conflict")))
+}
+
+func Test_DomainError_WrappedInnerError_ShouldBeFoundByIsAndAs(t *testing.T) {
+	// Arrange
+	inner := NewTransientError("database unavailable", context.DeadlineExceeded, nil)
+	err := NewDomainError(NewDebugTrackError("tracking", inner, map[string]interface{}{}), errTestOrderNotFoundCode)
+
+	// Act
+	var transientErr *TransientError
+	isTransient := errors.As(err, &transientErr)
+
+	assert.True(t, isTransient)
+	assert.Equal(t, inner, transientErr)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_AsDomainError_NestedDomainErrors_ShouldReturnNearest(t *testing.T) {
+	// Arrange
+	inner := NewDomainError(errors.New("not found"), errTestOrderNotFoundCode)
+	outer := NewDomainError(emperrors.Wrap(inner, "loading"), NewUnknownErrorCode(1, "unexpected"))
+
+	// Act
+	got, ok := AsDomainError(emperrors.Wrap(outer, "handling"))
+
+	assert.True(t, ok)
+	assert.Equal(t, outer, got)
+}
+
+func Test_AsDomainError_NoDomainError_ShouldReturnFalse(t *testing.T) {
+	// Act
+	got, ok := AsDomainError(errors.New("plain"))
+
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
+
+func Test_DecodeDomainError_EncodedChain_ShouldRebuildCodeDetailsAndCause(t *testing.T) {
+	// Arrange
+	inner := NewDomainError(errors.New("connection refused"), NewExternalCallErrorCode(5, "stock_unavailable"))
+	inner.SetDetail("sku", "ABC")
+	sent := NewDomainError(emperrors.Wrap(inner, "reserving stock"), errTestOrderNotFoundCode)
+	sent.SetDetail("order_id", "7")
+
+	// Act
+	data, err := EncodeDomainError(emperrors.Wrap(sent, "handling message"), true)
+	assert.NoError(t, err)
+	got, err := DecodeDomainError(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sent.Error(), got.Error())
+	assert.True(t, errors.Is(got, errTestOrderNotFoundCode))
+	assert.True(t, errors.Is(got, inner.ErrorCode()))
+	assert.Equal(t, map[string]interface{}{"order_id": "7", "sku": "ABC"}, got.Detail())
+	assert.Equal(t, sent.StackTrace(), got.StackTrace())
+}
+
+func Test_DecodeDomainError_JoinedCause_ShouldKeepEveryError(t *testing.T) {
+	// Arrange
+	stock := NewDomainError(errors.New("out of stock"), NewExternalCallErrorCode(5, "stock_unavailable"))
+	causes := &MultiError{}
+	causes.Add(errors.New("payment declined"))
+	causes.Add(stock)
+	sent := NewDomainError(causes, errTestOrderNotFoundCode)
+
+	// Act
+	data, err := EncodeDomainError(sent, false)
+	assert.NoError(t, err)
+	got, err := DecodeDomainError(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sent.Error(), got.Error())
+	assert.True(t, errors.Is(got, stock.ErrorCode()))
+	joined, ok := got.Unwrap().(interface{ Unwrap() []error })
+	assert.True(t, ok)
+	assert.Len(t, joined.Unwrap(), 2)
+}
+
+func Test_DecodeDomainError_ZeroCode_ShouldRebuildDomainError(t *testing.T) {
+	// Arrange
+	sent := NewDomainError(errors.New("not classified"), ErrorCode{})
+
+	// Act
+	data, err := EncodeDomainError(sent, false)
+	assert.NoError(t, err)
+	got, err := DecodeDomainError(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.ErrorCode().Code())
+	assert.Equal(t, sent.Error(), got.Error())
+}
+
+func Test_DomainError_JSON_ShouldKeepPreviousEncoding(t *testing.T) {
+	// Arrange
+	type payload struct {
+		Err *DomainError `json:"err"`
+	}
+	sent := payload{Err: NewDomainError(errors.New("not found"), errTestOrderNotFoundCode)}
+
+	// Act
+	data, err := json.Marshal(sent)
+	assert.NoError(t, err)
+	var got payload
+	err = json.Unmarshal([]byte(`{"err":{}}`), &got)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"err":{}}`, string(data))
+	assert.NotNil(t, got.Err)
+}
+
+func Test_DomainErrorJSON_ShouldRoundTripWireFormat(t *testing.T) {
+	// Arrange
+	type payload struct {
+		Err *DomainErrorJSON `json:"err"`
+	}
+	sent := NewDomainError(errors.New("not found"), errTestOrderNotFoundCode)
+	sent.SetDetail("order_id", "7")
+
+	// Act
+	data, err := json.Marshal(payload{Err: NewDomainErrorJSON(sent)})
+	assert.NoError(t, err)
+	var got payload
+	err = json.Unmarshal(data, &got)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"name":"order_not_found"`)
+	assert.True(t, errors.Is(got.Err, errTestOrderNotFoundCode))
+	assert.Equal(t, map[string]interface{}{"order_id": "7"}, got.Err.Detail())
+}
@@ -0,0 +1,191 @@
+package ccerrors
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/sts-solutions/base-code/ccredact"
+)
+
+// wireError is the JSON representation of an error chain.
+// DomainErrors are marked with Domain and keep their code, name and detail, any
+// other error keeps its message. The errors joining several errors, with an
+// Unwrap() []error method, keep all of them as Causes
+type wireError struct {
+	Domain  bool           `json:"domain,omitempty"`
+	Code    int            `json:"code,omitempty"`
+	Name    string         `json:"name,omitempty"`
+	Message string         `json:"message"`
+	Detail  map[string]any `json:"detail,omitempty"`
+	Stack   []string       `json:"stack,omitempty"`
+	Cause   *wireError     `json:"cause,omitempty"`
+	Causes  []*wireError   `json:"causes,omitempty"`
+}
+
+// remoteError is an error of the chain rebuilt from its JSON representation
+type remoteError struct {
+	message string
+	cause   error
+}
+
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.cause
+}
+
+// remoteJoinError is an error joining several errors rebuilt from its JSON representation
+type remoteJoinError struct {
+	message string
+	causes  []error
+}
+
+func (e *remoteJoinError) Error() string {
+	return e.message
+}
+
+func (e *remoteJoinError) Unwrap() []error {
+	return e.causes
+}
+
+// EncodeDomainError encodes the nearest DomainError in the error chain,
+// including its inner error chain, as JSON.
+// The stack traces of the DomainErrors are included when withStack is true
+func EncodeDomainError(err error, withStack bool) ([]byte, error) {
+	domainErr, ok := AsDomainError(err)
+	if !ok {
+		return nil, errors.New("error chain does not contain a domain error")
+	}
+	return json.Marshal(toWireError(domainErr, withStack))
+}
+
+// DecodeDomainError rebuilds a DomainError encoded with EncodeDomainError
+func DecodeDomainError(data []byte) (*DomainError, error) {
+	var we wireError
+	if err := json.Unmarshal(data, &we); err != nil {
+		return nil, err
+	}
+
+	domainErr, ok := fromWireError(&we).(*DomainError)
+	if !ok {
+		return nil, errors.New("data does not contain a domain error")
+	}
+	return domainErr, nil
+}
+
+// DomainErrorJSON opts a DomainError into the JSON wire format of
+// EncodeDomainError, without its stack trace, when it is part of a JSON
+// payload. A DomainError itself keeps its previous JSON encoding, an empty
+// object, so the existing payloads and their consumers are unchanged
+type DomainErrorJSON struct {
+	*DomainError
+}
+
+// NewDomainErrorJSON returns the DomainError opted into the JSON wire format
+func NewDomainErrorJSON(domainErr *DomainError) *DomainErrorJSON {
+	return &DomainErrorJSON{DomainError: domainErr}
+}
+
+// MarshalJSON encodes the DomainError with the JSON wire format
+func (e DomainErrorJSON) MarshalJSON() ([]byte, error) {
+	if e.DomainError == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(toWireError(e.DomainError, false))
+}
+
+// UnmarshalJSON rebuilds the DomainError from the JSON wire format
+func (e *DomainErrorJSON) UnmarshalJSON(data []byte) error {
+	domainErr, err := DecodeDomainError(data)
+	if err != nil {
+		return err
+	}
+	e.DomainError = domainErr
+	return nil
+}
+
+// Unwrap returns the DomainError
+func (e DomainErrorJSON) Unwrap() error {
+	if e.DomainError == nil {
+		return nil
+	}
+	return e.DomainError
+}
+
+func toWireError(err error, withStack bool) *wireError {
+	if err == nil {
+		return nil
+	}
+
+	domainErr, ok := err.(*DomainError)
+	if !ok {
+		we := &wireError{Message: ccredact.String(err.Error())}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, cause := range joined.Unwrap() {
+				if cause != nil {
+					we.Causes = append(we.Causes, toWireError(cause, withStack))
+				}
+			}
+			return we
+		}
+		we.Cause = toWireError(errors.Unwrap(err), withStack)
+		return we
+	}
+
+	we := &wireError{
+		Domain:  true,
+		Code:    domainErr.code.Code(),
+		Name:    domainErr.code.Name(),
+		Message: ccredact.String(domainErr.Error()),
+		Detail:  ccredact.Map(domainErr.detail),
+		Cause:   toWireError(domainErr.innerError, withStack),
+	}
+	if withStack {
+		we.Stack = domainErr.StackTrace()
+	}
+
+	return we
+}
+
+func fromWireError(we *wireError) error {
+	if we == nil {
+		return nil
+	}
+
+	if we.Causes != nil {
+		causes := make([]error, 0, len(we.Causes))
+		for _, cause := range we.Causes {
+			if cause != nil {
+				causes = append(causes, fromWireError(cause))
+			}
+		}
+		return &remoteJoinError{
+			message: we.Message,
+			causes:  causes,
+		}
+	}
+
+	cause := fromWireError(we.Cause)
+
+	// payloads encoded before Domain was added only have the code of the DomainErrors
+	if !we.Domain && we.Code == 0 {
+		return &remoteError{
+			message: we.Message,
+			cause:   cause,
+		}
+	}
+
+	code := FromCode(we.Code)
+	if code.Name() == "" {
+		code = code.WithName(we.Name)
+	}
+
+	return &DomainError{
+		code:        code,
+		detail:      we.Detail,
+		innerError:  cause,
+		remoteStack: we.Stack,
+	}
+}
@@ -13,6 +13,14 @@ const (
 	invalidCodePrefix  = 99
 )
 
+const (
+	UnknownCategory      = "unknown"
+	ValidationCategory   = "validation"
+	PersistenceCategory  = "persistence"
+	ExternalCallCategory = "external_call"
+	InvalidCodeCategory  = "invalid_code"
+)
+
 type ErrorCode struct {
 	prefix int
 	value  int
@@ -27,7 +35,14 @@ func new(name string, prefix, value int) ErrorCode {
 	}
 }
 
+// FromCode returns the ErrorCode for the given numeric code.
+// When the code has been registered the registered ErrorCode, including its
+// name, is returned
 func FromCode(code int) ErrorCode {
+	if registered, ok := Lookup(code); ok {
+		return registered
+	}
+
 	codeStr := fmt.Sprintf("%02d", code)
 	codePrefix := codeStr[:2]
 	actualCode := codeStr[2:]
@@ -53,12 +68,46 @@ func (ec ErrorCode) Value() int {
 func (ec ErrorCode) Name() string {
 	return ec.name
 }
+
+// WithName returns a copy of the ErrorCode with the given name
+func (ec ErrorCode) WithName(name string) ErrorCode {
+	ec.name = name
+	return ec
+}
+
+// Error implements the error interface so an ErrorCode can be used as the
+// target of errors.Is
+func (ec ErrorCode) Error() string {
+	if ec.name == "" {
+		return strconv.Itoa(ec.Code())
+	}
+	return ec.name
+}
+
 func (ec ErrorCode) Code() int {
 	codeStr := fmt.Sprintf("%d%d", ec.prefix, ec.value)
 	code, _ := strconv.Atoi(codeStr)
 	return code
 }
 
+// Category returns the category name of the ErrorCode prefix
+func (ec ErrorCode) Category() string {
+	switch ec.prefix {
+	case unkownPrefix:
+		return UnknownCategory
+	case validationPrefix:
+		return ValidationCategory
+	case persisetncePrefix:
+		return PersistenceCategory
+	case externalCallPrefix:
+		return ExternalCallCategory
+	case invalidCodePrefix:
+		return InvalidCodeCategory
+	default:
+		return ""
+	}
+}
+
 func (ec ErrorCode) IsUnknown() bool {
 	return ec.prefix == unkownPrefix
 }
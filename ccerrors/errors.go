@@ -4,4 +4,7 @@ import "errors"
 
 var (
 	ErrNotImplemented = errors.New("not implemented")
+	// ErrConflict is wrapped by persistence errors caused by a conflict with
+	// the stored data, e.g. a duplicated key, see IsConflict
+	ErrConflict = errors.New("conflict")
 )
@@ -0,0 +1,135 @@
+package ccerrors
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultFingerprintFrames is the number of stack frames used by Fingerprint
+const DefaultFingerprintFrames = 3
+
+// Fingerprinter computes a stable fingerprint of an error chain from the code
+// of its nearest DomainError, the types along the chain and the function names
+// of the top frames of its stack trace. Error messages are not used, so errors
+// that only differ in the IDs in their message get the same fingerprint
+type Fingerprinter struct {
+	// Frames is the number of stack frames used
+	Frames int
+	// StackTrace renders the stack trace, its excluded patterns are applied
+	StackTrace StackTrace
+}
+
+// Fingerprint returns the fingerprint of the error chain using DefaultFingerprintFrames
+func Fingerprint(err error) string {
+	return Fingerprinter{Frames: DefaultFingerprintFrames}.Fingerprint(err)
+}
+
+// Fingerprint returns the fingerprint of the error chain, or an empty string for a nil error
+func (f Fingerprinter) Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	h := fnv.New64a()
+
+	if domainErr, ok := AsDomainError(err); ok {
+		h.Write([]byte(strconv.Itoa(domainErr.ErrorCode().Code())))
+	}
+	h.Write([]byte{0})
+
+	walkErrorChain(err, func(e error) {
+		h.Write([]byte(fmt.Sprintf("%T", e)))
+		h.Write([]byte{0})
+	})
+
+	frames := f.StackTrace.GetFrames(err)
+	for i := 0; i < len(frames) && i < f.Frames; i++ {
+		h.Write([]byte(frames[i].Function))
+		h.Write([]byte{0})
+	}
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func walkErrorChain(err error, fn func(error)) {
+	for err != nil {
+		fn(err)
+
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, child := range multi.Unwrap() {
+				walkErrorChain(child, fn)
+			}
+			return
+		}
+
+		err = errors.Unwrap(err)
+	}
+}
+
+// FingerprintLimiter allows a limited number of occurrences per fingerprint in
+// a time window, e.g. to log only the first errors of an incident
+type FingerprintLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[string]*fingerprintWindow
+	swept   time.Time
+	now     func() time.Time
+}
+
+type fingerprintWindow struct {
+	start time.Time
+	count int
+}
+
+// NewFingerprintLimiter creates a FingerprintLimiter allowing limit occurrences
+// per fingerprint in each window
+func NewFingerprintLimiter(limit int, window time.Duration) *FingerprintLimiter {
+	return &FingerprintLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[string]*fingerprintWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow returns true when the error fingerprint has not reached the limit in the current window
+func (l *FingerprintLimiter) Allow(err error) bool {
+	return l.AllowFingerprint(Fingerprint(err))
+}
+
+// AllowFingerprint returns true when the fingerprint has not reached the limit in the current window
+func (l *FingerprintLimiter) AllowFingerprint(fingerprint string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.removeExpiredWindows(now)
+
+	w, ok := l.windows[fingerprint]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &fingerprintWindow{start: now}
+		l.windows[fingerprint] = w
+	}
+
+	w.count++
+	return w.count <= l.limit
+}
+
+// removeExpiredWindows removes the expired windows, at most once per window
+func (l *FingerprintLimiter) removeExpiredWindows(now time.Time) {
+	if now.Sub(l.swept) < l.window {
+		return
+	}
+	l.swept = now
+
+	for fingerprint, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, fingerprint)
+		}
+	}
+}
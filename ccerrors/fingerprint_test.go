@@ -0,0 +1,56 @@
+package ccerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFingerprintTestError(orderID int, code ErrorCode) error {
+	return NewDomainError(fmt.Errorf("order %d not found", orderID), code)
+}
+
+func Test_Fingerprint_ErrorsDifferingInMessage_ShouldBeEqual(t *testing.T) {
+	// Arrange
+	code := NewPersistenceErrorCode(21, "fingerprint_not_found")
+
+	// Act
+	first := Fingerprint(newFingerprintTestError(1, code))
+	second := Fingerprint(newFingerprintTestError(2, code))
+
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, second)
+}
+
+func Test_Fingerprint_DifferentCodeOrChain_ShouldDiffer(t *testing.T) {
+	// Arrange
+	code := NewPersistenceErrorCode(21, "fingerprint_not_found")
+	base := Fingerprint(newFingerprintTestError(1, code))
+
+	// Act
+	otherCode := Fingerprint(newFingerprintTestError(1, NewPersistenceErrorCode(22, "fingerprint_conflict")))
+	otherChain := Fingerprint(WrapTransient(newFingerprintTestError(1, code)))
+
+	assert.NotEqual(t, base, otherCode)
+	assert.NotEqual(t, base, otherChain)
+	assert.Equal(t, "", Fingerprint(nil))
+}
+
+func Test_FingerprintLimiter_LimitReached_ShouldAllowAgainInNextWindow(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	limiter := NewFingerprintLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+	err := errors.New("boom")
+
+	// Act & Assert
+	assert.True(t, limiter.Allow(err))
+	assert.True(t, limiter.Allow(err))
+	assert.False(t, limiter.Allow(err))
+
+	now = now.Add(time.Minute)
+	assert.True(t, limiter.Allow(err))
+}
@@ -1,50 +1,178 @@
 package ccerrors
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
 
+// multiErrorMuInit guards the lazy creation of the mutex of the zero value MultiErrors
+var multiErrorMuInit sync.Mutex
+
+// MultiError collects several errors. It is safe to add errors from several
+// goroutines. The mutex is referenced so a MultiError can be copied, the
+// copies share the lock of the original
 type MultiError struct {
-	errors []error
+	mu          *sync.Mutex
+	errors      []error
+	counts      []int
+	index       map[string]int
+	deduplicate bool
+}
+
+// MultiErrorEntry describes an error collected by a MultiError
+type MultiErrorEntry struct {
+	Message string `json:"message"`
+	Code    int    `json:"code,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Count   int    `json:"count"`
+}
+
+// NewDeduplicatedMultiError creates a MultiError that keeps identical errors
+// (same type and message) once, counting the number of times they were added
+func NewDeduplicatedMultiError() *MultiError {
+	return &MultiError{
+		mu:          &sync.Mutex{},
+		deduplicate: true,
+	}
+}
+
+// lock locks the mutex of the MultiError, creating it for zero values, and
+// returns it to be unlocked
+func (me *MultiError) lock() *sync.Mutex {
+	multiErrorMuInit.Lock()
+	if me.mu == nil {
+		me.mu = &sync.Mutex{}
+	}
+	mu := me.mu
+	multiErrorMuInit.Unlock()
+
+	mu.Lock()
+	return mu
 }
 
+// Add collects the error, nil errors and the MultiError itself are ignored
 func (me *MultiError) Add(err error) {
-	if err == nil {
+	if err == nil || err == error(me) {
 		return
 	}
 
+	defer me.lock().Unlock()
+
 	if me.errors == nil {
 		me.errors = []error{}
 	}
 
+	if me.deduplicate {
+		key := fmt.Sprintf("%T:%s", err, err.Error())
+		if i, ok := me.index[key]; ok {
+			me.counts[i]++
+			return
+		}
+		if me.index == nil {
+			me.index = make(map[string]int)
+		}
+		me.index[key] = len(me.errors)
+	}
+
 	me.errors = append(me.errors, err)
+	me.counts = append(me.counts, 1)
 }
 
 func (me *MultiError) HasErrors() bool {
+	defer me.lock().Unlock()
+
 	return len(me.errors) > 0
 }
 
 func (me *MultiError) Errors() []error {
+	defer me.lock().Unlock()
+
 	if me.errors == nil {
 		return []error{}
 	}
-	return me.errors
+	return append([]error{}, me.errors...)
+}
+
+// Unwrap returns the collected errors so errors.Is and errors.As can match any of them
+func (me *MultiError) Unwrap() []error {
+	return me.Errors()
+}
+
+// ErrorOrNil returns nil when no error has been collected, otherwise the MultiError
+func (me *MultiError) ErrorOrNil() error {
+	if me == nil || !me.HasErrors() {
+		return nil
+	}
+	return me
+}
+
+// Entries returns the collected errors, with their ErrorCode when they contain
+// a DomainError and the number of times they were added. The errors are
+// rendered after unlocking, so they can refer back to the MultiError
+func (me *MultiError) Entries() []MultiErrorEntry {
+	mu := me.lock()
+	errs := append([]error{}, me.errors...)
+	counts := append([]int{}, me.counts...)
+	mu.Unlock()
+
+	entries := make([]MultiErrorEntry, 0, len(errs))
+	for i, err := range errs {
+		entry := MultiErrorEntry{
+			Message: err.Error(),
+			Count:   counts[i],
+		}
+		if domainErr, ok := AsDomainError(err); ok {
+			entry.Code = domainErr.ErrorCode().Code()
+			entry.Name = domainErr.ErrorCode().Name()
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// GroupByErrorCode returns the collected errors grouped by the code of their
+// DomainError. Errors without a DomainError are grouped under code 0
+func (me *MultiError) GroupByErrorCode() map[int][]error {
+	groups := make(map[int][]error)
+	for _, err := range me.Errors() {
+		code := 0
+		if domainErr, ok := AsDomainError(err); ok {
+			code = domainErr.ErrorCode().Code()
+		}
+		groups[code] = append(groups[code], err)
+	}
+	return groups
+}
+
+// MarshalJSON renders the collected errors as a JSON array of entries
+func (me *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(me.Entries())
 }
 
 func (me *MultiError) Error() string {
-	if len(me.errors) == 0 {
+	entries := me.Entries()
+
+	if len(entries) == 0 {
 		return ""
 	}
 
-	if len(me.errors) == 1 {
-		return me.errors[0].Error()
+	if len(entries) == 1 && entries[0].Count == 1 {
+		return entries[0].Message
 	}
 
 	var sb strings.Builder
 
-	for i, err := range me.errors {
+	for i, entry := range entries {
 		if i != 0 {
 			sb.WriteString(";")
 		}
-		sb.WriteString(err.Error())
+		sb.WriteString(entry.Message)
+		if entry.Count > 1 {
+			sb.WriteString(fmt.Sprintf(" (x%d)", entry.Count))
+		}
 	}
 	return sb.String()
 }
@@ -0,0 +1,108 @@
+package ccerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MultiError_Unwrap_ShouldMatchChildrenWithIsAndAs(t *testing.T) {
+	// Arrange
+	code := NewPersistenceErrorCode(11, "multi_not_found")
+	me := &MultiError{}
+	me.Add(errors.New("first"))
+	me.Add(NewDomainError(nil, code))
+
+	// Act
+	err := me.ErrorOrNil()
+
+	var domainErr *DomainError
+	assert.True(t, errors.Is(err, code))
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "first;multi_not_found", err.Error())
+}
+
+func Test_MultiError_ErrorOrNil_NoErrors_ShouldReturnNil(t *testing.T) {
+	// Arrange
+	me := &MultiError{}
+	me.Add(nil)
+
+	// Act & Assert
+	assert.Nil(t, me.ErrorOrNil())
+}
+
+func Test_MultiError_ConcurrentAdd_ShouldCollectAllErrors(t *testing.T) {
+	// Arrange
+	me := &MultiError{}
+	wg := sync.WaitGroup{}
+
+	// Act
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			me.Add(errors.New("failed"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, me.Errors(), 100)
+}
+
+func Test_MultiError_Deduplicated_ShouldCountIdenticalErrors(t *testing.T) {
+	// Arrange
+	code := NewExternalCallErrorCode(11, "multi_upstream")
+	me := NewDeduplicatedMultiError()
+	me.Add(errors.New("timeout"))
+	me.Add(errors.New("timeout"))
+	me.Add(NewDomainError(nil, code))
+
+	// Act
+	data, err := json.Marshal(me)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"message": "timeout", "count": 2},
+		{"message": "multi_upstream", "code": 9311, "name": "multi_upstream", "count": 1}
+	]`, string(data))
+	assert.Equal(t, "timeout (x2);multi_upstream", me.Error())
+	assert.Len(t, me.GroupByErrorCode()[0], 1)
+}
+
+type parentAwareError struct {
+	parent *MultiError
+}
+
+func (e parentAwareError) Error() string {
+	return fmt.Sprintf("child of a parent with errors: %t", e.parent.HasErrors())
+}
+
+func Test_MultiError_ChildCallingTheParent_ShouldNotDeadlock(t *testing.T) {
+	// Arrange
+	me := &MultiError{}
+	me.Add(parentAwareError{parent: me})
+	me.Add(me)
+
+	// Act
+	msg := me.Error()
+
+	assert.Equal(t, "child of a parent with errors: true", msg)
+	assert.Len(t, me.Errors(), 1)
+}
+
+func Test_MultiError_Copy_ShouldKeepCollectingErrors(t *testing.T) {
+	// Arrange
+	var me MultiError
+	me.Add(errors.New("first"))
+
+	// Act
+	copied := me
+	copied.Add(errors.New("second"))
+
+	assert.Equal(t, "first;second", copied.Error())
+	assert.True(t, me.HasErrors())
+}
@@ -1,25 +1,56 @@
 package ccerrors
 
+import "github.com/sts-solutions/base-code/ccredact"
+
+const defaultTransientErrorMessage = "transient error"
+
+// TransientError is an error expected to succeed when retried
 type TransientError struct {
 	message    string
 	innerError error
 	details    map[string]interface{}
+	stack      Stack
 }
 
+// NewTransientError creates a TransientError with the given message
 func NewTransientError(message string, err error, details map[string]interface{}) *TransientError {
 	returnedErr := &TransientError{
-		message:    "transient error handling nats msg, nak-ing to retry later",
+		message:    message,
 		innerError: err,
 		details:    details,
+		stack:      captureStack(1),
 	}
 	return returnedErr
 }
 
+// WrapTransient marks the error as transient
+func WrapTransient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TransientError{innerError: err, stack: captureStack(1)}
+}
+
 func (e *TransientError) Error() string {
-	return e.message
+	return classifiedErrorMessage(e.message, e.innerError, defaultTransientErrorMessage)
+}
+
+// Unwrap returns the inner error
+func (e *TransientError) Unwrap() error {
+	return e.innerError
+}
+
+// Details returns the details of the error, redacted using the ccredact Policy
+func (e *TransientError) Details() map[string]interface{} {
+	return ccredact.Map(e.details)
+}
+
+// Class returns ClassTransient
+func (e *TransientError) Class() Class {
+	return ClassTransient
 }
 
-func (e *TransientError) Is(err error) bool {
-	_, ok := err.(*TransientError)
-	return ok
+// StackFrames returns the frames of the stack trace captured when the error was created
+func (e *TransientError) StackFrames() []Frame {
+	return e.stack.Frames()
 }
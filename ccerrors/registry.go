@@ -0,0 +1,168 @@
+package ccerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// LibraryCodeMin is the first ErrorCode value reserved for the error codes
+	// registered by the base-code packages, e.g. the recovered panics code.
+	// Services register their error codes with values outside the range
+	LibraryCodeMin = 9000
+	// LibraryCodeMax is the last ErrorCode value reserved for the base-code packages
+	LibraryCodeMax = 9999
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[int]CatalogEntry{}
+)
+
+// CatalogEntry describes a registered ErrorCode
+type CatalogEntry struct {
+	Code        int    `json:"code"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	errorCode   ErrorCode
+}
+
+// ErrorCode returns the registered ErrorCode
+func (ce CatalogEntry) ErrorCode() ErrorCode {
+	return ce.errorCode
+}
+
+// Register adds the ErrorCode to the registry.
+// It returns an error when an ErrorCode with the same prefix and value has
+// already been registered, or when its value is reserved for the base-code
+// packages, from LibraryCodeMin to LibraryCodeMax whatever the prefix: the
+// services registering values in that range must move them outside of it, or
+// MustRegister panics at startup
+func Register(code ErrorCode, description string) (ErrorCode, error) {
+	if IsLibraryCode(code) {
+		return code, fmt.Errorf("error code %d (%s) value is reserved for the base-code packages, from %d to %d",
+			code.Code(), code.Name(), LibraryCodeMin, LibraryCodeMax)
+	}
+	return register(code, description)
+}
+
+// MustRegisterLibrary adds an ErrorCode of a base-code package to the
+// registry, its value must be between LibraryCodeMin and LibraryCodeMax.
+// It panics when the value is not reserved or has already been registered
+func MustRegisterLibrary(code ErrorCode, description string) ErrorCode {
+	if !IsLibraryCode(code) {
+		panic(fmt.Errorf("error code %d (%s) value is not reserved for the base-code packages, from %d to %d",
+			code.Code(), code.Name(), LibraryCodeMin, LibraryCodeMax))
+	}
+
+	code, err := register(code, description)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// IsLibraryCode returns true when the ErrorCode value is reserved for the base-code packages
+func IsLibraryCode(code ErrorCode) bool {
+	return code.Value() >= LibraryCodeMin && code.Value() <= LibraryCodeMax
+}
+
+func register(code ErrorCode, description string) (ErrorCode, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if existing, ok := registry[code.Code()]; ok {
+		return code, fmt.Errorf("error code %d (%s) already registered as %s",
+			code.Code(), code.Name(), existing.Name)
+	}
+
+	registry[code.Code()] = CatalogEntry{
+		Code:        code.Code(),
+		Name:        code.Name(),
+		Category:    code.Category(),
+		Description: description,
+		errorCode:   code,
+	}
+
+	return code, nil
+}
+
+// MustRegister adds the ErrorCode to the registry and panics when an ErrorCode
+// with the same prefix and value has already been registered.
+// It is meant to be used when declaring package level error codes:
+//
+//	var ErrOrderNotFound = ccerrors.MustRegister(
+//		ccerrors.NewPersistenceErrorCode(1, "order_not_found"), "the order does not exist")
+func MustRegister(code ErrorCode, description string) ErrorCode {
+	code, err := Register(code, description)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// Lookup returns the registered ErrorCode for the given numeric code
+func Lookup(code int) (ErrorCode, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[code]
+	return entry.errorCode, ok
+}
+
+// LookupEntry returns the catalog entry of the registered numeric code
+func LookupEntry(code int) (CatalogEntry, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[code]
+	return entry, ok
+}
+
+// Catalog returns all the registered error codes sorted by code
+func Catalog() []CatalogEntry {
+	registryMu.RLock()
+	entries := make([]CatalogEntry, 0, len(registry))
+	for _, entry := range registry {
+		entries = append(entries, entry)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Code < entries[j].Code
+	})
+
+	return entries
+}
+
+// CatalogJSON returns the catalog of registered error codes as a JSON array
+func CatalogJSON() ([]byte, error) {
+	return json.MarshalIndent(Catalog(), "", "  ")
+}
+
+// CatalogMarkdown returns the catalog of registered error codes as a Markdown table
+func CatalogMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("| Code | Name | Category | Description |\n")
+	sb.WriteString("|------|------|----------|-------------|\n")
+
+	for _, entry := range Catalog() {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s |\n",
+			entry.Code,
+			escapeMarkdownCell(entry.Name),
+			entry.Category,
+			escapeMarkdownCell(entry.Description)))
+	}
+
+	return sb.String()
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,94 @@
+package ccerrors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MustRegister_DuplicatedCode_ShouldPanic(t *testing.T) {
+	// Arrange
+	MustRegister(NewValidationErrorCode(901, "registry_test_first"), "first")
+
+	// Act & Assert
+	assert.Panics(t, func() {
+		MustRegister(NewValidationErrorCode(901, "registry_test_second"), "second")
+	})
+}
+
+func Test_Register_LibraryCodeValue_ShouldFail(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     ErrorCode
+		reserved bool
+	}{
+		{"below the range", NewValidationErrorCode(LibraryCodeMin-1, "registry_test_below_library"), false},
+		{"first library value", NewValidationErrorCode(LibraryCodeMin, "registry_test_first_library"), true},
+		{"last library value", NewPersistenceErrorCode(LibraryCodeMax, "registry_test_last_library"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := Register(tt.code, "reserved")
+
+			_, registered := Lookup(tt.code.Code())
+			assert.Equal(t, tt.reserved, err != nil)
+			assert.Equal(t, !tt.reserved, registered)
+			if tt.reserved {
+				assert.Contains(t, err.Error(), "reserved for the base-code packages")
+				assert.Panics(t, func() { MustRegister(tt.code, "reserved") })
+			}
+		})
+	}
+}
+
+func Test_MustRegisterLibrary_NotReservedValue_ShouldPanic(t *testing.T) {
+	// Act
+	act := func() {
+		MustRegisterLibrary(NewUnknownErrorCode(905, "registry_test_not_reserved"), "not reserved")
+	}
+
+	assert.Panics(t, act)
+}
+
+func Test_FromCode_RegisteredCode_ShouldReturnName(t *testing.T) {
+	// Arrange
+	code := MustRegister(NewPersistenceErrorCode(902, "registry_test_not_found"), "not found")
+
+	// Act
+	got := FromCode(code.Code())
+
+	assert.Equal(t, code, got)
+	assert.Equal(t, "registry_test_not_found", got.Name())
+}
+
+func Test_FromCode_NotRegisteredCode_ShouldReturnEmptyName(t *testing.T) {
+	// Act
+	got := FromCode(93903)
+
+	assert.Equal(t, 93, got.Prefix())
+	assert.Equal(t, 903, got.Value())
+	assert.Equal(t, "", got.Name())
+}
+
+func Test_Catalog_RegisteredCode_ShouldBeExported(t *testing.T) {
+	// Arrange
+	code := MustRegister(NewExternalCallErrorCode(904, "registry_test_upstream"), "upstream | failed")
+
+	// Act
+	jsonCatalog, err := CatalogJSON()
+	markdown := CatalogMarkdown()
+
+	assert.NoError(t, err)
+	var entries []CatalogEntry
+	assert.NoError(t, json.Unmarshal(jsonCatalog, &entries))
+	assert.Contains(t, entries, CatalogEntry{
+		Code:        code.Code(),
+		Name:        "registry_test_upstream",
+		Category:    ExternalCallCategory,
+		Description: "upstream | failed",
+	})
+	assert.Contains(t, markdown, "| 93904 | registry_test_upstream | external_call | upstream \\| failed |")
+}
@@ -1,91 +1,110 @@
 package ccerrors
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 
 	"emperror.dev/errors"
-	"github.com/sts-solutions/base-code/ccerrors/withinnererr"
 )
 
+// compiledPatterns caches the compiled ExcludedStringPatterns
+var compiledPatterns sync.Map
+
+// StackTrace renders the stack trace of an error chain.
+// It understands emperror stack traces and the stack traces captured by the
+// ccerrors constructors, using the deepest one found in the chain
 type StackTrace struct {
 	ExcludedStringPatterns []string
 }
 
+// StackFramesProvider is implemented by the errors carrying a stack trace
+// captured by the ccerrors constructors
+type StackFramesProvider interface {
+	StackFrames() []Frame
+}
+
 func (st StackTrace) GetString(err error) string {
 	if err == nil {
 		return ""
 	}
 	stackTrace := st.GetStrings(err)
-	for len(stackTrace) == 0 {
-		if withInnerErr, ok := err.(withinnererr.WithInnerErr); ok {
-			innerErr := withInnerErr.GetInnerErr()
-			if innerErr == nil {
-				break
-			}
-			err = innerErr
-			stackTrace = st.GetStrings(err)
-			continue
-		}
-
-		return ""
-	}
 
 	resp := strings.Join(stackTrace, "\n")
 	return resp
 }
 
 func (st StackTrace) GetStrings(err error) []string {
+	frames := st.GetFrames(err)
+	resp := make([]string, 0, len(frames))
+
+	for _, frame := range frames {
+		resp = append(resp, frame.String())
+	}
+
+	return resp
+}
+
+// GetFrames returns the frames of the deepest stack trace in the error chain,
+// without the excluded ones
+func (st StackTrace) GetFrames(err error) []Frame {
 	if err == nil {
-		return []string{}
+		return []Frame{}
 	}
 
-	stackTrace := st.stack(err)
-	resp := []string{}
+	frames := st.stack(err)
+	resp := make([]Frame, 0, len(frames))
 
-	for _, stackItem := range stackTrace {
-		item := fmt.Sprintf("%+v", stackItem)
-		if st.shouldExcludeStackTraceItem(item) {
+	for _, frame := range frames {
+		if st.shouldExcludeStackTraceItem(frame.String()) {
 			continue
 		}
-		resp = append(resp, item)
+		resp = append(resp, frame)
 	}
 
 	return resp
 }
 
-func (st StackTrace) stack(err error) (stackTrace errors.StackTrace) {
-	if err == nil {
-		return nil
-	}
-
-	for {
-		stackError, ok := err.(interface {
-			StackTrace() errors.StackTrace
-		})
-		if ok {
-			stackTrace = stackError.StackTrace()
+func (st StackTrace) stack(err error) (frames []Frame) {
+	for err != nil {
+		switch stackError := err.(type) {
+		case StackFramesProvider:
+			frames = stackError.StackFrames()
+		case interface{ StackTrace() errors.StackTrace }:
+			frames = emperrorFrames(stackError.StackTrace())
 		}
 
-		u, ok := err.(interface {
-			Unwrap() error
-		})
-		if !ok {
-			break
-		}
-		err = u.Unwrap()
+		err = errors.Unwrap(err)
 	}
 
-	return stackTrace
+	return frames
 }
 
 func (st StackTrace) shouldExcludeStackTraceItem(item string) bool {
 	for _, exc := range st.ExcludedStringPatterns {
-		if match, _ := regexp.Match(exc, []byte(item)); match {
+		if re := compilePattern(exc); re != nil && re.MatchString(item) {
 			return true
 		}
 	}
 
 	return false
 }
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	// invalid patterns are cached as nil and never match
+	re, _ := regexp.Compile(pattern)
+	compiledPatterns.Store(pattern, re)
+	return re
+}
+
+func emperrorFrames(stackTrace errors.StackTrace) []Frame {
+	pcs := make(Stack, len(stackTrace))
+	for i, frame := range stackTrace {
+		pcs[i] = uintptr(frame)
+	}
+	return pcs.Frames()
+}
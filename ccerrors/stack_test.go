@@ -0,0 +1,65 @@
+package ccerrors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StackTrace_GetFrames_DomainError_ShouldStartAtCaller(t *testing.T) {
+	// Arrange
+	err := emperrors.WithMessage(NewDomainError(errors.New("boom"), NewUnknownErrorCode(1, "unexpected")), "handling")
+
+	// Act
+	frames := StackTrace{}.GetFrames(err)
+
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "Test_StackTrace_GetFrames_DomainError_ShouldStartAtCaller"))
+	assert.True(t, strings.HasSuffix(frames[0].File, "stack_test.go"))
+}
+
+func Test_StackTrace_GetStrings_ExcludedPatterns_ShouldSkipFrames(t *testing.T) {
+	// Arrange
+	err := NewTransientError("db down", nil, nil)
+	st := StackTrace{ExcludedStringPatterns: []string{`testing\.`, `runtime\.`}}
+
+	// Act
+	got := st.GetStrings(err)
+
+	assert.Len(t, got, 1)
+	assert.Contains(t, got[0], "Test_StackTrace_GetStrings_ExcludedPatterns_ShouldSkipFrames")
+}
+
+func Test_StackTrace_GetFrames_EmperrorStack_ShouldBeRendered(t *testing.T) {
+	// Arrange
+	err := emperrors.New("boom")
+
+	// Act
+	frames := StackTrace{}.GetFrames(err)
+
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "Test_StackTrace_GetFrames_EmperrorStack_ShouldBeRendered"))
+}
+
+func Test_NewDebugTrackError_NilDetails_ShouldNotPanic(t *testing.T) {
+	// Act
+	err := NewDebugTrackError("panic caught", nil, nil)
+
+	assert.NotNil(t, err.Details())
+	assert.NotEmpty(t, err.StackFrames())
+	assert.Contains(t, err.Details()[StackTraceDetailKey], "Test_NewDebugTrackError_NilDetails_ShouldNotPanic")
+}
+
+func Test_SetStackDepth_Zero_ShouldDisableCapture(t *testing.T) {
+	// Arrange
+	SetStackDepth(0)
+	defer SetStackDepth(defaultStackDepth)
+
+	// Act
+	err := NewDomainError(nil, NewUnknownErrorCode(1, "unexpected"))
+
+	assert.Empty(t, err.StackFrames())
+}
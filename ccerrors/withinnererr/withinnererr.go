@@ -1,5 +1,7 @@
 package withinnererr
 
+// WithInnerErr is implemented by errors exposing their inner error.
+// Deprecated: implement Unwrap() error and use the standard errors package instead.
 type WithInnerErr interface {
 	GetInnerErr() error
 }
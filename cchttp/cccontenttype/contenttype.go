@@ -22,6 +22,7 @@ const (
 	FormURLEncoded
 	TextXML
 	TextPlain
+	ApplicationProblemJSON
 )
 
 // marshalFuncFunc encodes any value to bytes
@@ -81,6 +82,15 @@ var contentTypes = map[EncodingContentType]contentType{
 			return fmt.Errorf("expected *string, got %T", v)
 		},
 	},
+	ApplicationProblemJSON: {
+		Name: "application/problem+json",
+		marshalFunc: func(v any) ([]byte, error) {
+			return json.Marshal(v)
+		},
+		unmarshalFunc: func(data []byte, v any) error {
+			return json.Unmarshal(data, v)
+		},
+	},
 }
 
 // Name returns the name of the ContentType
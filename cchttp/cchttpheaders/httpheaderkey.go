@@ -14,6 +14,8 @@ const (
 	RequestID
 	XMethod
 	XOperator
+	IdempotencyKey
+	RetryAfter
 )
 
 var httpHeaderNames = map[HTTPHeaderKey]string{
@@ -27,6 +29,8 @@ var httpHeaderNames = map[HTTPHeaderKey]string{
 	RequestID:      "Request-Id",
 	XMethod:        "X-Method",
 	XOperator:      "X-Operator",
+	IdempotencyKey: "Idempotency-Key",
+	RetryAfter:     "Retry-After",
 }
 
 func (t HTTPHeaderKey) Name() string {
@@ -0,0 +1,375 @@
+package cchttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/cclogger"
+	"github.com/sts-solutions/base-code/ccmetrics"
+)
+
+const (
+	// CircuitHostDetailKey is the DomainError detail with the host of an open circuit
+	CircuitHostDetailKey = "host"
+	// CircuitRetryAtDetailKey is the DomainError detail with the time the open circuit is probed again
+	CircuitRetryAtDetailKey = "retry_at"
+)
+
+// ErrCircuitOpenCode is the ErrorCode of the DomainErrors returned while the
+// circuit of the destination host is open, its value is in the range reserved
+// for the base-code packages
+var ErrCircuitOpenCode = ccerrors.MustRegisterLibrary(
+	ccerrors.NewExternalCallErrorCode(ccerrors.LibraryCodeMin, "circuit_open"),
+	"the circuit breaker of the destination host is open")
+
+// CircuitState is the state of a circuit breaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets the calls through, counting their failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails the calls fast until the open duration has elapsed
+	CircuitOpen
+	// CircuitHalfOpen lets the probe calls through, closing the circuit when all of them succeed
+	CircuitHalfOpen
+)
+
+var circuitStateNames = map[CircuitState]string{
+	CircuitClosed:   "closed",
+	CircuitOpen:     "open",
+	CircuitHalfOpen: "half_open",
+}
+
+func (s CircuitState) String() string {
+	return circuitStateNames[s]
+}
+
+type circuitBreakerClient struct {
+	client              Client
+	consecutiveFailures int
+	failureRate         float64
+	minRequests         int
+	window              time.Duration
+	openDuration        time.Duration
+	halfOpenProbes      int
+	isFailure           func(resp *http.Response, err error) bool
+	logger              cclogger.Logger
+	metrics             ccmetrics.CircuitBreakerMetricsHandler
+	now                 func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// NewCircuitBreakerClient decorates the client with a circuit breaker per
+// destination host. By default the circuit opens after 5 consecutive failures,
+// stays open 30 seconds and closes after 1 successful half-open probe.
+// Transport errors and 5xx responses are failures
+func NewCircuitBreakerClient(client Client) *circuitBreakerClient {
+	return &circuitBreakerClient{
+		client:              client,
+		consecutiveFailures: 5,
+		window:              time.Minute,
+		openDuration:        30 * time.Second,
+		halfOpenProbes:      1,
+		isFailure:           DefaultCircuitFailure,
+		now:                 time.Now,
+		breakers:            make(map[string]*circuitBreaker),
+	}
+}
+
+// DefaultCircuitFailure returns true for transport errors and 5xx responses.
+// Calls cancelled by the caller are ignored by the circuit before the
+// condition is evaluated, they are neither failures nor successes
+func DefaultCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// WithConsecutiveFailures opens the circuit after the number of consecutive
+// failures, zero disables it
+func (c *circuitBreakerClient) WithConsecutiveFailures(failures int) *circuitBreakerClient {
+	c.consecutiveFailures = failures
+	return c
+}
+
+// WithFailureRate opens the circuit when the failure rate, between 0 and 1, of
+// at least minRequests calls in the window is reached. The counts are reset
+// every window
+func (c *circuitBreakerClient) WithFailureRate(rate float64, minRequests int, window time.Duration) *circuitBreakerClient {
+	c.failureRate = rate
+	c.minRequests = minRequests
+	c.window = window
+	return c
+}
+
+// WithOpenDuration sets how long the circuit stays open before being probed
+func (c *circuitBreakerClient) WithOpenDuration(duration time.Duration) *circuitBreakerClient {
+	c.openDuration = duration
+	return c
+}
+
+// WithHalfOpenProbes sets the number of calls let through while half-open,
+// all of them must succeed to close the circuit
+func (c *circuitBreakerClient) WithHalfOpenProbes(probes int) *circuitBreakerClient {
+	c.halfOpenProbes = max(probes, 1)
+	return c
+}
+
+// WithFailureCondition sets the condition of the failed calls, see DefaultCircuitFailure
+func (c *circuitBreakerClient) WithFailureCondition(fn func(resp *http.Response, err error) bool) *circuitBreakerClient {
+	c.isFailure = fn
+	return c
+}
+
+// WithLogger logs the state changes of the circuits
+func (c *circuitBreakerClient) WithLogger(logger cclogger.Logger) *circuitBreakerClient {
+	c.logger = logger
+	return c
+}
+
+// WithMetrics reports the state changes of the circuits
+func (c *circuitBreakerClient) WithMetrics(metrics ccmetrics.CircuitBreakerMetricsHandler) *circuitBreakerClient {
+	c.metrics = metrics
+	return c
+}
+
+// State returns the state of the circuit of the host
+func (c *circuitBreakerClient) State(host string) CircuitState {
+	breaker := c.breaker(host)
+
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	return breaker.state
+}
+
+func (c *circuitBreakerClient) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+func (c *circuitBreakerClient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(cccontenttype.Key.String(), contentType)
+	return c.Do(req)
+}
+
+// Do sends the request when the circuit of its host is not open, otherwise it
+// returns a ccerrors.ThrottledError wrapping a DomainError with
+// ErrCircuitOpenCode, to be retried once the circuit is probed again
+func (c *circuitBreakerClient) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	breaker := c.breaker(host)
+
+	generation, allowed, from, to := breaker.allow(c, c.now())
+	c.notify(req.Context(), host, from, to)
+	if !allowed {
+		return nil, c.openError(host, breaker)
+	}
+
+	resp, err := c.client.Do(req)
+
+	from, to = breaker.record(c, c.now(), generation, c.outcome(resp, err))
+	c.notify(req.Context(), host, from, to)
+
+	return resp, err
+}
+
+// outcome returns the outcome of the call counted by the circuit
+func (c *circuitBreakerClient) outcome(resp *http.Response, err error) callOutcome {
+	if err != nil && errors.Is(err, context.Canceled) {
+		return callIgnored
+	}
+	if c.isFailure(resp, err) {
+		return callFailed
+	}
+	return callSucceeded
+}
+
+func (c *circuitBreakerClient) breaker(host string) *circuitBreaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	breaker, ok := c.breakers[host]
+	if !ok {
+		breaker = &circuitBreaker{windowStart: c.now()}
+		c.breakers[host] = breaker
+	}
+	return breaker
+}
+
+func (c *circuitBreakerClient) openError(host string, breaker *circuitBreaker) error {
+	breaker.mu.Lock()
+	retryAt := breaker.openedAt.Add(c.openDuration)
+	breaker.mu.Unlock()
+
+	domainErr := ccerrors.NewDomainError(
+		errors.Errorf("circuit breaker of %s is open", host), ErrCircuitOpenCode)
+	domainErr.SetDetail(CircuitHostDetailKey, host)
+	domainErr.SetDetail(CircuitRetryAtDetailKey, retryAt.Format(time.RFC3339))
+	return ccerrors.WrapThrottled(domainErr, max(retryAt.Sub(c.now()), 0))
+}
+
+func (c *circuitBreakerClient) notify(ctx context.Context, host string, from, to CircuitState) {
+	if from == to {
+		return
+	}
+
+	if c.metrics != nil {
+		c.metrics.CircuitBreakerStateChange(host, from.String(), to.String())
+	}
+
+	if c.logger == nil {
+		return
+	}
+	fields := []cclogger.LogField{
+		{Key: "host", Value: host},
+		{Key: "from", Value: from.String()},
+		{Key: "to", Value: to.String()},
+	}
+	if to == CircuitOpen {
+		c.logger.Warn(ctx, "circuit breaker opened", fields...)
+		return
+	}
+	c.logger.Info(ctx, "circuit breaker state changed", fields...)
+}
+
+// callOutcome is the result of a call as counted by the circuit
+type callOutcome int
+
+const (
+	callSucceeded callOutcome = iota
+	callFailed
+	// callIgnored leaves the counters of the circuit unchanged
+	callIgnored
+)
+
+// circuitBreaker is the state of the circuit of a host
+type circuitBreaker struct {
+	mu                  sync.Mutex
+	state               CircuitState
+	generation          uint64
+	consecutiveFailures int
+	requests            int
+	failures            int
+	windowStart         time.Time
+	openedAt            time.Time
+	probes              int
+	probeSuccesses      int
+}
+
+// allow returns the generation of the circuit state admitting the call, true
+// when the call can be sent, and the state transition
+func (b *circuitBreaker) allow(c *circuitBreakerClient, now time.Time) (uint64, bool, CircuitState, CircuitState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from := b.state
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < c.openDuration {
+			return b.generation, false, from, b.state
+		}
+		b.transition(CircuitHalfOpen)
+		b.probes = 0
+		b.probeSuccesses = 0
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probes >= c.halfOpenProbes {
+			return b.generation, false, from, b.state
+		}
+		b.probes++
+	default:
+		if now.Sub(b.windowStart) >= c.window {
+			b.resetWindow(now)
+		}
+	}
+	return b.generation, true, from, b.state
+}
+
+// record counts the outcome of a call admitted in the generation, returning
+// the state transition. Calls admitted before the last state change, like the
+// ones sent while closed that complete while half-open, are not counted
+func (b *circuitBreaker) record(c *circuitBreakerClient, now time.Time, generation uint64,
+	outcome callOutcome) (CircuitState, CircuitState) {
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from := b.state
+	if generation != b.generation {
+		return from, b.state
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		switch outcome {
+		case callIgnored:
+			b.probes--
+		case callFailed:
+			b.open(now)
+		default:
+			b.probeSuccesses++
+			if b.probeSuccesses >= c.halfOpenProbes {
+				b.transition(CircuitClosed)
+				b.consecutiveFailures = 0
+				b.resetWindow(now)
+			}
+		}
+	case CircuitClosed:
+		if outcome == callIgnored {
+			break
+		}
+		b.requests++
+		b.consecutiveFailures++
+		if outcome == callFailed {
+			b.failures++
+		} else {
+			b.consecutiveFailures = 0
+		}
+		if b.shouldTrip(c) {
+			b.open(now)
+		}
+	}
+	return from, b.state
+}
+
+func (b *circuitBreaker) shouldTrip(c *circuitBreakerClient) bool {
+	if c.consecutiveFailures > 0 && b.consecutiveFailures >= c.consecutiveFailures {
+		return true
+	}
+	return c.failureRate > 0 && b.requests >= c.minRequests &&
+		float64(b.failures)/float64(b.requests) >= c.failureRate
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.transition(CircuitOpen)
+	b.openedAt = now
+}
+
+// transition changes the state, starting a new generation so the calls
+// admitted in the previous state are not counted
+func (b *circuitBreaker) transition(state CircuitState) {
+	b.state = state
+	b.generation++
+}
+
+func (b *circuitBreaker) resetWindow(now time.Time) {
+	b.requests = 0
+	b.failures = 0
+	b.windowStart = now
+}
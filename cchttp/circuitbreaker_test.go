@@ -0,0 +1,195 @@
+package cchttp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccretry"
+)
+
+type testStatusClient struct {
+	statuses []int
+	calls    int
+}
+
+func (c *testStatusClient) Get(url string) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *testStatusClient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *testStatusClient) Do(req *http.Request) (*http.Response, error) {
+	status := c.statuses[min(c.calls, len(c.statuses)-1)]
+	c.calls++
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+type testCircuitMetrics struct {
+	changes []string
+}
+
+func (m *testCircuitMetrics) CircuitBreakerStateChange(recipient, from, to string) {
+	m.changes = append(m.changes, recipient+" "+from+">"+to)
+}
+
+func Test_CircuitBreakerClient_ConsecutiveFailures_ShouldOpenProbeAndClose(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	inner := &testStatusClient{statuses: []int{500, 500, 200}}
+	metrics := &testCircuitMetrics{}
+	client := NewCircuitBreakerClient(inner).
+		WithConsecutiveFailures(2).
+		WithOpenDuration(time.Minute).
+		WithMetrics(metrics)
+	client.now = func() time.Time { return now }
+
+	// Act
+	_, _ = client.Get("http://orders/1")
+	_, _ = client.Get("http://orders/1")
+	_, openErr := client.Get("http://orders/1")
+	otherHost := client.State("payments")
+
+	now = now.Add(time.Minute)
+	resp, probeErr := client.Get("http://orders/1")
+
+	require.Error(t, openErr)
+	assert.True(t, errors.Is(openErr, ErrCircuitOpenCode))
+	domainErr, ok := ccerrors.AsDomainError(openErr)
+	require.True(t, ok)
+	assert.Equal(t, "orders", domainErr.Detail()[CircuitHostDetailKey])
+	assert.Equal(t, 3, inner.calls)
+	assert.Equal(t, CircuitClosed, otherHost)
+
+	assert.NoError(t, probeErr)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, CircuitClosed, client.State("orders"))
+	assert.Equal(t, []string{"orders closed>open", "orders open>half_open", "orders half_open>closed"}, metrics.changes)
+}
+
+func Test_CircuitBreakerClient_FailureRate_ShouldOpenAndReopenOnFailedProbe(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	inner := &testStatusClient{statuses: []int{200, 503, 200, 503, 503}}
+	client := NewCircuitBreakerClient(inner).
+		WithConsecutiveFailures(0).
+		WithFailureRate(0.5, 4, time.Minute).
+		WithOpenDuration(time.Second).
+		WithHalfOpenProbes(2)
+	client.now = func() time.Time { return now }
+
+	// Act
+	for range 4 {
+		_, _ = client.Get("http://orders/1")
+	}
+	opened := client.State("orders")
+
+	now = now.Add(time.Second)
+	_, _ = client.Get("http://orders/1")
+
+	assert.Equal(t, CircuitOpen, opened)
+	assert.Equal(t, CircuitOpen, client.State("orders"))
+	assert.Equal(t, 5, inner.calls)
+}
+
+type testErrorClient struct {
+	testStatusClient
+	err error
+}
+
+func (c *testErrorClient) Do(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		c.calls++
+		return nil, c.err
+	}
+	return c.testStatusClient.Do(req)
+}
+
+func Test_CircuitBreakerClient_CancelledCalls_ShouldNotChangeTheCircuit(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	inner := &testErrorClient{testStatusClient: testStatusClient{statuses: []int{500}}}
+	client := NewCircuitBreakerClient(inner).
+		WithConsecutiveFailures(2).
+		WithOpenDuration(time.Minute)
+	client.now = func() time.Time { return now }
+
+	// Act
+	_, _ = client.Get("http://orders/1")
+	inner.err = context.Canceled
+	_, _ = client.Get("http://orders/1")
+	inner.err = nil
+	_, _ = client.Get("http://orders/1")
+	opened := client.State("orders")
+
+	now = now.Add(time.Minute)
+	inner.err = context.Canceled
+	_, _ = client.Get("http://orders/1")
+	cancelledProbe := client.State("orders")
+	inner.err = nil
+	inner.statuses = []int{200}
+	_, probeErr := client.Get("http://orders/1")
+
+	// Assert
+	assert.Equal(t, CircuitOpen, opened)
+	assert.Equal(t, CircuitHalfOpen, cancelledProbe)
+	assert.NoError(t, probeErr)
+	assert.Equal(t, CircuitClosed, client.State("orders"))
+}
+
+func Test_CircuitBreaker_CallAdmittedWhileClosed_ShouldNotCountAsProbe(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	client := NewCircuitBreakerClient(&testStatusClient{}).
+		WithConsecutiveFailures(1).
+		WithOpenDuration(time.Minute)
+	breaker := &circuitBreaker{windowStart: now}
+
+	// Act
+	staleGeneration, _, _, _ := breaker.allow(client, now)
+	generation, _, _, _ := breaker.allow(client, now)
+	breaker.record(client, now, generation, callFailed)
+	now = now.Add(time.Minute)
+	_, probeAllowed, _, _ := breaker.allow(client, now)
+	breaker.record(client, now, staleGeneration, callSucceeded)
+
+	// Assert
+	assert.True(t, probeAllowed)
+	assert.Equal(t, CircuitHalfOpen, breaker.state)
+}
+
+func Test_CircuitBreakerClient_OpenCircuit_ShouldBeRetriedAfterTheOpenDuration(t *testing.T) {
+	// Arrange
+	inner := &testStatusClient{statuses: []int{500, 200}}
+	client := NewCircuitBreakerClient(inner).
+		WithConsecutiveFailures(1).
+		WithOpenDuration(50 * time.Millisecond)
+	_, _ = client.Get("http://orders/1")
+
+	_, openErr := client.Get("http://orders/1")
+	retry := ccretry.NewRetry(func() error {
+		_, err := client.Get("http://orders/1")
+		return err
+	}).WithMaxAttempts(2)
+
+	// Act
+	start := time.Now()
+	resp, err := retry.Run()
+
+	// Assert
+	assert.Equal(t, ccerrors.ClassThrottled, ccerrors.Classify(openErr))
+	assert.True(t, errors.Is(openErr, ErrCircuitOpenCode))
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.NumberOfAttempts())
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	assert.Equal(t, CircuitClosed, client.State("orders"))
+}
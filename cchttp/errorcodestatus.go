@@ -0,0 +1,90 @@
+package cchttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+var (
+	errorCodeStatusesMu sync.RWMutex
+	errorCodeStatuses   = map[int]int{}
+)
+
+// RegisterErrorCodeStatus overrides the HTTP status code returned by FrontError
+// for the given ErrorCode, e.g. to return 404 for a "not found" domain code
+func RegisterErrorCodeStatus(code ccerrors.ErrorCode, httpCode int) {
+	errorCodeStatusesMu.Lock()
+	defer errorCodeStatusesMu.Unlock()
+
+	errorCodeStatuses[code.Code()] = httpCode
+}
+
+// UnregisterErrorCodeStatus removes the HTTP status code override for the given ErrorCode
+func UnregisterErrorCodeStatus(code ccerrors.ErrorCode) {
+	errorCodeStatusesMu.Lock()
+	defer errorCodeStatusesMu.Unlock()
+
+	delete(errorCodeStatuses, code.Code())
+}
+
+// StatusCodeFromDomainError returns the HTTP status code for a DomainError.
+// Registered overrides take precedence, otherwise the ErrorCode prefix is used:
+// - validation: BadRequest (400)
+// - persistence: Conflict (409) on ccerrors.IsConflict, InternalServerError (500)
+// - external call: BadGateway (502), GatewayTimeout (504) on deadline exceeded
+// - unknown and any other prefix: InternalServerError (500)
+func StatusCodeFromDomainError(domainErr *ccerrors.DomainError) int {
+	code := domainErr.ErrorCode()
+
+	switch {
+	case code.IsValidation():
+		return statusCodeFromErrorCode(code, http.StatusBadRequest)
+	case code.IsPersistence():
+		if ccerrors.IsConflict(domainErr) {
+			return statusCodeFromErrorCode(code, http.StatusConflict)
+		}
+		return statusCodeFromErrorCode(code, http.StatusInternalServerError)
+	case code.IsExternalCall():
+		if errors.Is(domainErr, context.DeadlineExceeded) {
+			return statusCodeFromErrorCode(code, http.StatusGatewayTimeout)
+		}
+		return statusCodeFromErrorCode(code, http.StatusBadGateway)
+	default:
+		return statusCodeFromErrorCode(code, http.StatusInternalServerError)
+	}
+}
+
+// hasStatusMapping returns true when the ErrorCode has a registered HTTP code
+// or a prefix mapped by StatusCodeFromDomainError
+func hasStatusMapping(code ccerrors.ErrorCode) bool {
+	if code.IsValidation() || code.IsPersistence() || code.IsExternalCall() {
+		return true
+	}
+
+	errorCodeStatusesMu.RLock()
+	defer errorCodeStatusesMu.RUnlock()
+
+	_, ok := errorCodeStatuses[code.Code()]
+	return ok
+}
+
+// statusCodeFromErrorCode returns the registered HTTP code of the ErrorCode,
+// or the default one
+func statusCodeFromErrorCode(code ccerrors.ErrorCode, defaultHTTPCode int) int {
+	errorCodeStatusesMu.RLock()
+	defer errorCodeStatusesMu.RUnlock()
+
+	if httpCode, ok := errorCodeStatuses[code.Code()]; ok {
+		return httpCode
+	}
+	return defaultHTTPCode
+}
+
+func domainErrorResponse(domainErr *ccerrors.DomainError, err error) *ErrorResponse {
+	return GetErrorResponseWithCode(StatusCodeFromDomainError(domainErr),
+		domainErr.ErrorCode().Code(), err)
+}
@@ -0,0 +1,45 @@
+package cchttp
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/sts-solutions/base-code/ccerrors/ccmessages"
+)
+
+var messageCatalog atomic.Pointer[ccmessages.Catalog]
+
+// SetMessageCatalog sets the catalog of the user-facing messages used by
+// LocalizedFrontError. A nil catalog disables the translation
+func SetMessageCatalog(catalog *ccmessages.Catalog) {
+	messageCatalog.Store(catalog)
+}
+
+// MessageCatalog returns the catalog of the user-facing messages, nil when not set
+func MessageCatalog() *ccmessages.Catalog {
+	return messageCatalog.Load()
+}
+
+// LocalizedFrontError returns the ErrorResponse of FrontError with a safe,
+// user-facing message in the locale of the context (see ccmessages.Middleware).
+// When the catalog has no message for the error the HTTP status text is used.
+// The internal message is only kept in the InnerErr, to be logged.
+// Without a message catalog it behaves like FrontError
+func LocalizedFrontError(ctx context.Context, err error) *ErrorResponse {
+	errResp := FrontError(err)
+
+	catalog := messageCatalog.Load()
+	if catalog == nil {
+		return errResp
+	}
+
+	msg, ok := catalog.Message(ctx, err)
+	if !ok {
+		msg = http.StatusText(errResp.HTTPCode)
+	}
+	errResp.Message = msg
+	errResp.localized = true
+
+	return errResp
+}
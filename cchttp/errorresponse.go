@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccredact"
 	"github.com/sts-solutions/base-code/ccvalidation"
 )
 
@@ -15,9 +17,18 @@ type ErrorResponse struct {
 	// Message is the error message
 	Message string `json:"message"`
 	// Code is the error code
-	Code     int   `json:"code"`
-	HTTPCode int   `json:"-"`
-	InnerErr error `json:"-"`
+	Code int `json:"code"`
+	// Errors are the field validation failures of the inner ccvalidation.Result
+	Errors []ccvalidation.FieldError `json:"errors,omitempty"`
+	// Warnings are the validation warnings of the inner ccvalidation.Result
+	Warnings []ccvalidation.FieldError `json:"warnings,omitempty"`
+	// DomainError is the DomainError found in the inner error, only set by WithDomainError
+	DomainError *ccerrors.DomainErrorJSON `json:"domain_error,omitempty"`
+	HTTPCode    int                       `json:"-"`
+	InnerErr    error                     `json:"-"`
+
+	// localized is true when Message is the user-facing message of the catalog
+	localized bool
 }
 
 // Error returns a string representation of the error.
@@ -26,10 +37,16 @@ func (e ErrorResponse) Error() string {
 }
 
 // FrontError returns an error HTTP code (depending on error type) and response body
-// - EndRequest (408)
+// - DomainError with a registered or prefix mapped ErrorCode: see StatusCodeFromDomainError
+// - ccvalidation.Result: BadRequest (400), see BadRequest for coded failures
 // - RequestTimeout (408)
-// - InternalServerError (500)
+// - InternalServerError (500), with the ErrorCode of any other DomainError
 func FrontError(err error) (errReponse *ErrorResponse) {
+	domainErr, isDomainErr := ccerrors.AsDomainError(err)
+	if isDomainErr && hasStatusMapping(domainErr.ErrorCode()) {
+		return domainErrorResponse(domainErr, err)
+	}
+
 	if errors.Is(err, ccvalidation.Result{}) {
 		return BadRequest(err)
 	}
@@ -38,6 +55,10 @@ func FrontError(err error) (errReponse *ErrorResponse) {
 		return requestTimeout(err)
 	}
 
+	if isDomainErr {
+		return domainErrorResponse(domainErr, err)
+	}
+
 	return InternalServerError(err)
 }
 
@@ -51,9 +72,32 @@ func ServiceUnavailable(err error) *ErrorResponse {
 	return getErrorResponse(http.StatusServiceUnavailable, err)
 }
 
-// EndRequest returns a RequestTimeout (408) HTTP code and response body
+// BadRequest returns a BadRequest (400) HTTP code and response body.
+// The field failures and warnings of a ccvalidation.Result inner error are
+// added as Errors and Warnings. When a failure carries an ErrorCode, the first
+// one is the response code, and its registered HTTP code overrides the 400
 func BadRequest(err error) *ErrorResponse {
-	return getErrorResponse(http.StatusBadRequest, err)
+	errResp := getErrorResponse(http.StatusBadRequest, err)
+
+	var result ccvalidation.Result
+	if !errors.As(err, &result) {
+		return errResp
+	}
+
+	if result.IsFailure() {
+		errResp.Errors = result.GetFieldErrors()
+	}
+	errResp.Warnings = result.GetWarnings()
+
+	for _, fieldErr := range errResp.Errors {
+		if code, ok := fieldErr.GetErrorCode(); ok {
+			errResp.Code = code.Code()
+			errResp.HTTPCode = statusCodeFromErrorCode(code, http.StatusBadRequest)
+			break
+		}
+	}
+
+	return errResp
 }
 
 // RequestTimeout returns a RequestTimeout (408) HTTP code and response body
@@ -67,9 +111,10 @@ func InternalServerError(err error) *ErrorResponse {
 }
 
 // GetErrorResponseWithCode returns an ErrorResponse with code.
+// The message is redacted using the ccredact Policy.
 func GetErrorResponseWithCode(httpCode int, code int, err error) *ErrorResponse {
 	errorReponse := ErrorResponse{
-		Message:  fmt.Sprintf("%v", err),
+		Message:  ccredact.String(fmt.Sprintf("%v", err)),
 		Code:     code,
 		InnerErr: err,
 		HTTPCode: httpCode,
@@ -80,7 +125,7 @@ func GetErrorResponseWithCode(httpCode int, code int, err error) *ErrorResponse
 
 func getErrorResponse(httpCode int, err error) *ErrorResponse {
 	errorResponse := ErrorResponse{
-		Message:  fmt.Sprintf("%v", err),
+		Message:  ccredact.String(fmt.Sprintf("%v", err)),
 		InnerErr: err,
 		HTTPCode: httpCode,
 	}
@@ -88,7 +133,32 @@ func getErrorResponse(httpCode int, err error) *ErrorResponse {
 	return &errorResponse
 }
 
+// WithDomainError adds the nearest DomainError of the inner error to the
+// response body so the receiving service can rebuild it with GetDomainError
+func (e *ErrorResponse) WithDomainError() *ErrorResponse {
+	if domainErr, ok := ccerrors.AsDomainError(e.InnerErr); ok {
+		e.DomainError = ccerrors.NewDomainErrorJSON(domainErr)
+	}
+	return e
+}
+
+// GetDomainError returns the DomainError sent in the response body
+func (e ErrorResponse) GetDomainError() (*ccerrors.DomainError, bool) {
+	if e.DomainError == nil || e.DomainError.DomainError == nil {
+		return nil, false
+	}
+	return e.DomainError.DomainError, true
+}
+
 // GetInnerErr returns the inner error
 func (e ErrorResponse) GetInnerErr() error {
 	return e.InnerErr
 }
+
+// Unwrap returns the inner error, or the received DomainError when there is no inner error
+func (e ErrorResponse) Unwrap() error {
+	if domainErr, ok := e.GetDomainError(); ok && e.InnerErr == nil {
+		return domainErr
+	}
+	return e.InnerErr
+}
@@ -0,0 +1,161 @@
+package cchttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccerrors/ccmessages"
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+func Test_FrontError_DomainErrorByPrefix_ShouldMapHTTPCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     ccerrors.ErrorCode
+		inner    error
+		expected int
+	}{
+		{"validation", ccerrors.NewValidationErrorCode(1, "invalid_order"), nil, http.StatusBadRequest},
+		{"persistence", ccerrors.NewPersistenceErrorCode(1, "db_failure"), nil, http.StatusInternalServerError},
+		{"persistence conflict", ccerrors.NewPersistenceErrorCode(3, "order_exists"), emperrors.Wrap(ccerrors.ErrConflict, "inserting order"), http.StatusConflict},
+		{"persistence duplicate key", ccerrors.NewPersistenceErrorCode(3, "order_exists"), &pgconn.PgError{Code: "23505"}, http.StatusConflict},
+		{"external call", ccerrors.NewExternalCallErrorCode(1, "payments_failure"), nil, http.StatusBadGateway},
+		{"external call deadline exceeded", ccerrors.NewExternalCallErrorCode(1, "payments_failure"), emperrors.Wrap(context.DeadlineExceeded, "calling payments"), http.StatusGatewayTimeout},
+		{"unknown", ccerrors.NewUnknownErrorCode(1, "unknown"), nil, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			err := emperrors.Wrap(ccerrors.NewDomainError(tt.inner, tt.code), "handling request")
+
+			// Act
+			got := FrontError(err)
+
+			assert.Equal(t, tt.expected, got.HTTPCode)
+			assert.Equal(t, tt.code.Code(), got.Code)
+		})
+	}
+}
+
+func Test_FrontError_UnmappedDomainErrorDeadlineExceeded_ShouldReturnRequestTimeout(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewUnknownErrorCode(2, "processing_failure")
+	err := ccerrors.NewDomainError(emperrors.Wrap(context.DeadlineExceeded, "processing order"), code)
+
+	// Act
+	got := FrontError(err)
+
+	assert.Equal(t, http.StatusRequestTimeout, got.HTTPCode)
+}
+
+func Test_FrontError_RegisteredOverride_ShouldReturnOverriddenHTTPCode(t *testing.T) {
+	// Arrange
+	notFound := ccerrors.NewPersistenceErrorCode(2, "order_not_found")
+	RegisterErrorCodeStatus(notFound, http.StatusNotFound)
+	defer UnregisterErrorCodeStatus(notFound)
+
+	// Act
+	got := FrontError(ccerrors.NewDomainError(errors.New("order 1 not found"), notFound))
+
+	assert.Equal(t, http.StatusNotFound, got.HTTPCode)
+	assert.Equal(t, notFound.Code(), got.Code)
+}
+
+func Test_FrontError_NoDomainError_ShouldKeepExistingMapping(t *testing.T) {
+	// Arrange
+	result := ccvalidation.Result{}
+	result.AddErrorMessage("name is required")
+
+	// Act
+	badRequest := FrontError(result)
+	timeout := FrontError(emperrors.Wrap(context.DeadlineExceeded, "calling"))
+	internal := FrontError(errors.New("boom"))
+
+	assert.Equal(t, http.StatusBadRequest, badRequest.HTTPCode)
+	assert.Equal(t, result.GetFieldErrors(), badRequest.Errors)
+	assert.Equal(t, http.StatusRequestTimeout, timeout.HTTPCode)
+	assert.Equal(t, http.StatusInternalServerError, internal.HTTPCode)
+}
+
+func Test_LocalizedFrontError_WithCatalog_ShouldHideInternalMessage(t *testing.T) {
+	// Arrange
+	catalog := ccmessages.MustNewCatalog("en")
+	assert.NoError(t, catalog.AddMessages("es", map[string]string{ccerrors.ValidationCategory: "Solicitud no válida"}))
+	SetMessageCatalog(catalog)
+	defer SetMessageCatalog(nil)
+
+	ctx := ccmessages.WithAcceptLanguage(context.Background(), "es")
+	validationErr := ccerrors.NewDomainError(errors.New("column order.total is null"), ccerrors.NewValidationErrorCode(1, "invalid_order"))
+
+	// Act
+	translated := LocalizedFrontError(ctx, validationErr)
+	untranslated := LocalizedFrontError(ctx, errors.New("dial tcp 10.0.0.1:5432: refused"))
+
+	assert.Equal(t, "Solicitud no válida", translated.Message)
+	assert.Equal(t, validationErr, translated.InnerErr)
+	assert.Equal(t, http.StatusText(http.StatusInternalServerError), untranslated.Message)
+}
+
+func Test_FrontError_CodedValidationFailure_ShouldUseFailureErrorCode(t *testing.T) {
+	// Arrange
+	closed := ccerrors.NewValidationErrorCode(302, "order_closed")
+	RegisterErrorCodeStatus(closed, http.StatusConflict)
+	defer UnregisterErrorCodeStatus(closed)
+
+	result := ccvalidation.Result{}
+	result.AddFieldError("id", "required", "is required", nil)
+	result.AddCodedError("status", closed, "is closed")
+	result.AddWarning("delivery_date", "holiday", "is a public holiday", nil)
+
+	// Act
+	got := FrontError(emperrors.Wrap(result, "validating order"))
+
+	assert.Equal(t, http.StatusConflict, got.HTTPCode)
+	assert.Equal(t, closed.Code(), got.Code)
+	assert.Equal(t, closed.Code(), got.Errors[1].ErrorCode)
+	assert.Equal(t, result.GetWarnings(), got.Warnings)
+}
+
+func Test_WriteResponse_WithWarnings_ShouldReturnThemWithData(t *testing.T) {
+	// Arrange
+	result := ccvalidation.Result{}
+	result.AddWarning("delivery_date", "holiday", "is a public holiday", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	err := WriteResponse(rec, http.StatusCreated, map[string]string{"id": "1"}, result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"data":{"id":"1"},"warnings":[
+		{"path":"delivery_date","code":"holiday","message":"is a public holiday","severity":"warning"}
+	]}`, rec.Body.String())
+}
+
+func Test_ErrorResponse_WithDomainError_ShouldRebuildItFromBody(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewPersistenceErrorCode(2, "order_not_found")
+	sent := ccerrors.NewDomainError(errors.New("order 7 not found"), code)
+	sent.SetDetail("order_id", "7")
+
+	// Act
+	body, err := json.Marshal(FrontError(sent).WithDomainError())
+	assert.NoError(t, err)
+	var got ErrorResponse
+	err = json.Unmarshal(body, &got)
+
+	assert.NoError(t, err)
+	domainErr, ok := got.GetDomainError()
+	assert.True(t, ok)
+	assert.True(t, errors.Is(got, code))
+	assert.Equal(t, map[string]interface{}{"order_id": "7"}, domainErr.Detail())
+}
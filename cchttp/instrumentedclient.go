@@ -0,0 +1,194 @@
+package cchttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccmetrics"
+	"github.com/sts-solutions/base-code/ccmiddlewares/cccorrelation"
+	"github.com/sts-solutions/base-code/ccredact"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the default tracer of the instrumented clients
+	TracerName = "github.com/sts-solutions/base-code/cchttp"
+
+	// UnknownRoute is the route label of the requests without a route template
+	UnknownRoute = "unknown"
+	// ErrorStatusCode is the status code label of the requests without response
+	ErrorStatusCode = "error"
+)
+
+type routeTemplateKey struct{}
+
+// WithRouteTemplate returns a context with the route template of the request,
+// e.g. /orders/{id}, used by the instrumented clients to label the metrics
+// and the spans without the high cardinality of the URL paths
+func WithRouteTemplate(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeTemplateKey{}, route)
+}
+
+// RouteTemplate returns the route template of the context, or UnknownRoute
+func RouteTemplate(ctx context.Context) string {
+	if route, ok := ctx.Value(routeTemplateKey{}).(string); ok && route != "" {
+		return route
+	}
+	return UnknownRoute
+}
+
+type instrumentedClient struct {
+	client     Client
+	recipient  string
+	metrics    ccmetrics.DownstreamCallsMetricsHandler
+	tracer     oteltrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewInstrumentedClient decorates the client with an OpenTelemetry client span
+// per request, started with the global tracer provider, and with the W3C trace
+// context and the correlation ID of the request context injected as headers
+func NewInstrumentedClient(client Client) *instrumentedClient {
+	return &instrumentedClient{
+		client:     client,
+		tracer:     otel.Tracer(TracerName),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+// WithRecipient sets the recipient label of the metrics, the request host by default
+func (c *instrumentedClient) WithRecipient(recipient string) *instrumentedClient {
+	c.recipient = recipient
+	return c
+}
+
+// WithMetrics records the downstream calls metrics, labelled by recipient,
+// method, route template and status code
+func (c *instrumentedClient) WithMetrics(metrics ccmetrics.DownstreamCallsMetricsHandler) *instrumentedClient {
+	c.metrics = metrics
+	return c
+}
+
+// WithTracer sets the tracer of the client spans
+func (c *instrumentedClient) WithTracer(tracer oteltrace.Tracer) *instrumentedClient {
+	c.tracer = tracer
+	return c
+}
+
+// WithPropagator sets the propagator injecting the trace context headers
+func (c *instrumentedClient) WithPropagator(propagator propagation.TextMapPropagator) *instrumentedClient {
+	c.propagator = propagator
+	return c
+}
+
+func (c *instrumentedClient) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+func (c *instrumentedClient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(cccontenttype.Key.String(), contentType)
+	return c.Do(req)
+}
+
+// Do sends a copy of the request with the trace context and correlation ID
+// headers in a client span, recording the downstream calls metrics
+func (c *instrumentedClient) Do(req *http.Request) (*http.Response, error) {
+	route := RouteTemplate(req.Context())
+
+	ctx, span := c.tracer.Start(req.Context(), spanName(req.Method, route),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(requestAttributes(req, route)...),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	c.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if req.Header.Get(cccorrelation.Key.String()) == "" {
+		if correlationID := cccorrelation.GetCorrelationID(ctx); correlationID != "" {
+			req.Header.Set(cccorrelation.Key.String(), correlationID)
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	duration := time.Since(start).Seconds()
+
+	statusCode := ErrorStatusCode
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	default:
+		statusCode = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			span.SetAttributes(semconv.ErrorTypeKey.String(statusCode))
+		}
+	}
+
+	c.record(req, route, statusCode, duration, err != nil || resp.StatusCode >= http.StatusBadRequest)
+
+	return resp, err
+}
+
+func (c *instrumentedClient) record(req *http.Request, route, statusCode string, duration float64, failed bool) {
+	if c.metrics == nil {
+		return
+	}
+
+	recipient := c.recipient
+	if recipient == "" {
+		recipient = req.URL.Host
+	}
+
+	c.metrics.HttpRequestDuration(recipient, req.Method, route, statusCode, duration)
+	c.metrics.HttpRequestCounterInc(recipient, req.Method, route, statusCode)
+	if failed {
+		c.metrics.HttpErrorInc(recipient, req.Method, route, statusCode)
+	}
+}
+
+// spanName returns the span name of the HTTP semantic conventions, "{method} {route}"
+func spanName(method, route string) string {
+	if route == UnknownRoute {
+		return method
+	}
+	return method + " " + route
+}
+
+func requestAttributes(req *http.Request, route string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(ccredact.String(req.URL.String())),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+	if route != UnknownRoute {
+		attrs = append(attrs, semconv.URLTemplate(route))
+	}
+
+	return attrs
+}
@@ -0,0 +1,171 @@
+package cchttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccmiddlewares/cccorrelation"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// testTracer records the spans it starts, they have a sampled span context so
+// the trace context is propagated
+type testTracer struct {
+	embedded.Tracer
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string,
+	opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+
+	config := oteltrace.NewSpanStartConfig(opts...)
+	span := &testSpan{
+		name:       name,
+		kind:       config.SpanKind(),
+		attributes: config.Attributes(),
+		spanContext: oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID:    oteltrace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:     oteltrace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, byte(len(t.spans) + 1)},
+			TraceFlags: oteltrace.FlagsSampled,
+		}),
+	}
+	t.spans = append(t.spans, span)
+	return oteltrace.ContextWithSpan(ctx, span), span
+}
+
+// ended returns the ended spans of the tracer
+func (t *testTracer) ended() []*testSpan {
+	var spans []*testSpan
+	for _, span := range t.spans {
+		if span.ended {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+type testSpan struct {
+	noop.Span
+	name        string
+	kind        oteltrace.SpanKind
+	attributes  []attribute.KeyValue
+	statusCode  codes.Code
+	spanContext oteltrace.SpanContext
+	ended       bool
+}
+
+func (s *testSpan) SpanContext() oteltrace.SpanContext {
+	return s.spanContext
+}
+
+func (s *testSpan) IsRecording() bool {
+	return !s.ended
+}
+
+func (s *testSpan) SetStatus(code codes.Code, description string) {
+	s.statusCode = code
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *testSpan) End(options ...oteltrace.SpanEndOption) {
+	s.ended = true
+}
+
+type testDownstreamMetrics struct {
+	durations []string
+	counts    []string
+	errors    []string
+}
+
+func (m *testDownstreamMetrics) HttpRequestDuration(recipient, method, path, code string, duration float64) {
+	m.durations = append(m.durations, recipient+" "+method+" "+path+" "+code)
+}
+
+func (m *testDownstreamMetrics) HttpRequestCounterInc(recipient, method, path, code string) {
+	m.counts = append(m.counts, recipient+" "+method+" "+path+" "+code)
+}
+
+func (m *testDownstreamMetrics) HttpErrorInc(recipient, method, path, code string) {
+	m.errors = append(m.errors, recipient+" "+method+" "+path+" "+code)
+}
+
+func Test_InstrumentedClient_Do_ShouldTraceAndRecordMetrics(t *testing.T) {
+	// Arrange
+	var gotHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	metrics := &testDownstreamMetrics{}
+	client := NewInstrumentedClient(http.DefaultClient).
+		WithRecipient("orders").
+		WithMetrics(metrics).
+		WithTracer(tracer)
+
+	ctx := context.WithValue(context.Background(), cccorrelation.Key, "corr-1")
+	req, err := NewRequestBuilder().
+		WithContext(ctx).
+		WithHTTPClient(client).
+		WithURL(server.URL + "/orders/42").
+		WithHTTPMethod(http.MethodGet).
+		WithRouteTemplate("/orders/{id}").
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	require.NoError(t, err)
+	spans := tracer.ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /orders/{id}", span.name)
+	assert.Equal(t, oteltrace.SpanKindClient, span.kind)
+	assert.Equal(t, codes.Error, span.statusCode)
+	assert.Contains(t, span.attributes, attribute.String("http.request.method", http.MethodGet))
+	assert.Contains(t, span.attributes, attribute.Int("http.response.status_code", http.StatusNotFound))
+	assert.Contains(t, span.attributes, attribute.String("url.template", "/orders/{id}"))
+
+	assert.Contains(t, gotHeaders.Get("Traceparent"), span.spanContext.TraceID().String())
+	assert.Equal(t, "corr-1", gotHeaders.Get(cccorrelation.Key.String()))
+
+	assert.Equal(t, []string{"orders GET /orders/{id} 404"}, metrics.durations)
+	assert.Equal(t, []string{"orders GET /orders/{id} 404"}, metrics.counts)
+	assert.Equal(t, []string{"orders GET /orders/{id} 404"}, metrics.errors)
+}
+
+func Test_InstrumentedClient_ConnectionError_ShouldRecordErrorStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	tracer := &testTracer{}
+	metrics := &testDownstreamMetrics{}
+	client := NewInstrumentedClient(http.DefaultClient).
+		WithMetrics(metrics).
+		WithTracer(tracer)
+
+	// Act
+	_, err := client.Get(server.URL + "/orders")
+
+	require.Error(t, err)
+	require.Len(t, tracer.ended(), 1)
+	assert.Equal(t, "GET", tracer.ended()[0].name)
+	assert.Equal(t, codes.Error, tracer.ended()[0].statusCode)
+	host := server.Listener.Addr().String()
+	assert.Equal(t, []string{host + " GET unknown error"}, metrics.errors)
+}
@@ -0,0 +1,264 @@
+package cchttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+const (
+	// DefaultProblemType is the problem type used when none is set (RFC 9457 section 4.2.1)
+	DefaultProblemType = "about:blank"
+
+	problemCodeExtension     = "code"
+	problemNameExtension     = "name"
+	problemErrorsExtension   = "errors"
+	problemFieldsExtension   = "fields"
+	problemWarningsExtension = "warnings"
+	problemDetailsExtension  = "details"
+)
+
+var (
+	problemDetailKeysMu sync.RWMutex
+	problemDetailKeys   = map[string]struct{}{}
+)
+
+// RegisterProblemDetailKeys allows the DomainError details with the keys to be
+// sent to the clients in the "details" extension member of the problem details.
+// Details with other keys are internal and never reach the clients
+func RegisterProblemDetailKeys(keys ...string) {
+	problemDetailKeysMu.Lock()
+	defer problemDetailKeysMu.Unlock()
+
+	for _, key := range keys {
+		problemDetailKeys[key] = struct{}{}
+	}
+}
+
+// UnregisterProblemDetailKeys stops sending the DomainError details with the keys
+func UnregisterProblemDetailKeys(keys ...string) {
+	problemDetailKeysMu.Lock()
+	defer problemDetailKeysMu.Unlock()
+
+	for _, key := range keys {
+		delete(problemDetailKeys, key)
+	}
+}
+
+// publicDetails returns the registered details of the DomainError, redacted
+func publicDetails(domainErr *ccerrors.DomainError) map[string]any {
+	problemDetailKeysMu.RLock()
+	defer problemDetailKeysMu.RUnlock()
+
+	var details map[string]any
+	for k, v := range domainErr.RedactedDetail() {
+		if _, ok := problemDetailKeys[k]; !ok {
+			continue
+		}
+		if details == nil {
+			details = make(map[string]any)
+		}
+		details[k] = v
+	}
+	return details
+}
+
+// ProblemDetails represents an RFC 9457 problem details object
+// swagger:model
+type ProblemDetails struct {
+	// Type is a URI reference that identifies the problem type
+	Type string `json:"type,omitempty"`
+	// Title is a short, human-readable summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies this occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+	// Extensions holds the extension members
+	Extensions map[string]any `json:"-"`
+}
+
+// NewProblemDetails creates a ProblemDetails from an ErrorResponse.
+// The detail is the user-facing message of the message catalog, the one of
+// LocalizedFrontError or else of the default locale, or the HTTP status text
+// when there is none: the internal error message never reaches the clients.
+// The ErrorResponse code, the DomainError details registered with
+// RegisterProblemDetailKeys ("details"), the validation failures found in the
+// inner error, as messages ("errors") and as ccvalidation.FieldErrors
+// ("fields"), and the validation warnings ("warnings") are added as extension members
+func NewProblemDetails(errResp *ErrorResponse) ProblemDetails {
+	problem := ProblemDetails{
+		Type:   DefaultProblemType,
+		Title:  http.StatusText(errResp.HTTPCode),
+		Status: errResp.HTTPCode,
+		Detail: problemDetail(errResp),
+	}
+
+	if errResp.Code != 0 {
+		problem.SetExtension(problemCodeExtension, errResp.Code)
+	}
+
+	if domainErr, ok := ccerrors.AsDomainError(errResp.InnerErr); ok {
+		if name := domainErr.ErrorCode().Name(); name != "" {
+			problem.SetExtension(problemNameExtension, name)
+		}
+		if details := publicDetails(domainErr); details != nil {
+			problem.SetExtension(problemDetailsExtension, details)
+		}
+	}
+
+	var result ccvalidation.Result
+	if errors.As(errResp.InnerErr, &result) && result.IsFailure() {
+		problem.SetExtension(problemErrorsExtension, result.GetErrorMessages())
+		problem.SetExtension(problemFieldsExtension, result.GetFieldErrors())
+	}
+	if len(errResp.Warnings) > 0 {
+		problem.SetExtension(problemWarningsExtension, errResp.Warnings)
+	}
+
+	return problem
+}
+
+// ProblemDetailsFromError creates a ProblemDetails from the ErrorResponse returned by FrontError
+func ProblemDetailsFromError(err error) ProblemDetails {
+	return NewProblemDetails(FrontError(err))
+}
+
+// LocalizedProblemDetails creates a ProblemDetails from the ErrorResponse
+// returned by LocalizedFrontError, its detail is in the locale of the context
+func LocalizedProblemDetails(ctx context.Context, err error) ProblemDetails {
+	return NewProblemDetails(LocalizedFrontError(ctx, err))
+}
+
+// problemDetail returns the user-facing message of the ErrorResponse
+func problemDetail(errResp *ErrorResponse) string {
+	if errResp.localized {
+		return errResp.Message
+	}
+	if catalog := messageCatalog.Load(); catalog != nil && errResp.InnerErr != nil {
+		if msg, ok := catalog.Message(context.Background(), errResp.InnerErr); ok {
+			return msg
+		}
+	}
+	return http.StatusText(errResp.HTTPCode)
+}
+
+// DecodeProblemDetails decodes an application/problem+json body
+func DecodeProblemDetails(body []byte) (problem ProblemDetails, err error) {
+	err = cccontenttype.ApplicationProblemJSON.UnmarshalFunc()(body, &problem)
+	return problem, err
+}
+
+// IsProblemDetailsContentType returns true when the content type header value is application/problem+json
+func IsProblemDetailsContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == cccontenttype.ApplicationProblemJSON.Name()
+}
+
+// WithType sets the problem type
+func (p ProblemDetails) WithType(problemType string) ProblemDetails {
+	p.Type = problemType
+	return p
+}
+
+// WithInstance sets the problem instance
+func (p ProblemDetails) WithInstance(instance string) ProblemDetails {
+	p.Instance = instance
+	return p
+}
+
+// SetExtension sets an extension member.
+// Keys of the standard members are ignored
+func (p *ProblemDetails) SetExtension(key string, value any) {
+	if isProblemDetailsMember(key) {
+		return
+	}
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+}
+
+// Extension returns the extension member for the given key
+func (p ProblemDetails) Extension(key string) (any, bool) {
+	v, ok := p.Extensions[key]
+	return v, ok
+}
+
+// Error returns a string representation of the problem
+func (p ProblemDetails) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Title)
+	}
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+}
+
+// MarshalJSON marshals the standard members and the extension members at the same level
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		if !isProblemDetailsMember(k) {
+			members[k] = v
+		}
+	}
+
+	type problemDetails ProblemDetails
+	standard, err := json.Marshal(problemDetails(p))
+	if err != nil {
+		return nil, err
+	}
+
+	standardMembers := map[string]any{}
+	if err := json.Unmarshal(standard, &standardMembers); err != nil {
+		return nil, err
+	}
+	for k, v := range standardMembers {
+		members[k] = v
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON unmarshals the standard members and keeps any other member as an extension
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	type problemDetails ProblemDetails
+	var standard problemDetails
+	if err := json.Unmarshal(data, &standard); err != nil {
+		return err
+	}
+
+	members := map[string]any{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*p = ProblemDetails(standard)
+	p.Extensions = nil
+	for k, v := range members {
+		p.SetExtension(k, v)
+	}
+
+	return nil
+}
+
+func isProblemDetailsMember(key string) bool {
+	switch key {
+	case "type", "title", "status", "detail", "instance":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package cchttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccerrors/ccmessages"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+func Test_ProblemDetailsFromError_DomainError_ShouldAddCodeAndDetails(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewValidationErrorCode(7, "invalid_quantity")
+	domainErr := ccerrors.NewDomainError(errors.New("quantity must be positive"), code)
+	domainErr.SetDetail("quantity", -1)
+	domainErr.SetDetail("query", "SELECT 1")
+	RegisterProblemDetailKeys("quantity")
+	defer UnregisterProblemDetailKeys("quantity")
+
+	// Act
+	problem := ProblemDetailsFromError(domainErr)
+	body, err := json.Marshal(problem)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "Bad Request",
+		"code": 917,
+		"name": "invalid_quantity",
+		"details": {"quantity": -1}
+	}`, string(body))
+}
+
+func Test_ProblemDetailsFromError_ReservedDetailKeys_ShouldNotOverrideMembers(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewValidationErrorCode(7, "invalid_quantity")
+	domainErr := ccerrors.NewDomainError(errors.New("quantity must be positive"), code)
+	reserved := []string{"code", "errors", "fields", "type", "status"}
+	for _, key := range reserved {
+		domainErr.SetDetail(key, "overridden")
+	}
+	RegisterProblemDetailKeys(reserved...)
+	defer UnregisterProblemDetailKeys(reserved...)
+
+	// Act
+	problem := ProblemDetailsFromError(domainErr)
+	body, err := json.Marshal(problem)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "Bad Request",
+		"code": 917,
+		"name": "invalid_quantity",
+		"details": {
+			"code": "overridden",
+			"errors": "overridden",
+			"fields": "overridden",
+			"type": "overridden",
+			"status": "overridden"
+		}
+	}`, string(body))
+}
+
+func Test_ProblemDetailsFromError_InternalError_ShouldNotSendTheErrorMessage(t *testing.T) {
+	// Arrange
+	err := emperrors.Wrap(errors.New("dial tcp 10.0.0.1:5432: connection refused"), "loading order")
+
+	// Act
+	problem := ProblemDetailsFromError(err)
+	body, marshalErr := json.Marshal(problem)
+
+	assert.NoError(t, marshalErr)
+	assert.Equal(t, http.StatusText(http.StatusInternalServerError), problem.Detail)
+	assert.NotContains(t, string(body), "10.0.0.1")
+	assert.NotContains(t, string(body), "loading order")
+}
+
+func Test_LocalizedProblemDetails_WithCatalog_ShouldUseTheCatalogMessage(t *testing.T) {
+	// Arrange
+	catalog := ccmessages.MustNewCatalog("en")
+	assert.NoError(t, catalog.AddMessages("es", map[string]string{ccerrors.ValidationCategory: "Solicitud no válida"}))
+	SetMessageCatalog(catalog)
+	defer SetMessageCatalog(nil)
+
+	ctx := ccmessages.WithAcceptLanguage(context.Background(), "es")
+	err := ccerrors.NewDomainError(errors.New("column order.total is null"), ccerrors.NewValidationErrorCode(1, "invalid_order"))
+
+	// Act
+	problem := LocalizedProblemDetails(ctx, err)
+
+	assert.Equal(t, "Solicitud no válida", problem.Detail)
+}
+
+func Test_ProblemDetailsFromError_ValidationResult_ShouldAddErrors(t *testing.T) {
+	// Arrange
+	result := ccvalidation.Result{}
+	result.AddErrorMessage("name is required")
+	result.AddErrorMessage("email is required")
+
+	// Act
+	problem := ProblemDetailsFromError(result)
+
+	errs, ok := problem.Extension("errors")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"name is required", "email is required"}, errs)
+}
+
+func Test_RequestDo_ProblemDetailsResponse_ShouldReturnProblemDetailsError(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(cccontenttype.Key.String(), cccontenttype.ApplicationProblemJSON.Name())
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"title":"Not Found","status":404,"detail":"order 7 not found","order_id":"7"}`))
+	}))
+	defer server.Close()
+
+	req, err := NewRequestBuilder().
+		WithContext(context.Background()).
+		WithURL(server.URL).
+		WithHTTPMethod(http.MethodGet).
+		WithDefaultHTTPClient().
+		WithExpectedStatusCode(http.StatusOK).
+		Build()
+	assert.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	var problem ProblemDetails
+	assert.True(t, errors.As(err, &problem))
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "order 7 not found", problem.Detail)
+	orderID, _ := problem.Extension("order_id")
+	assert.Equal(t, "7", orderID)
+}
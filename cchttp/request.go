@@ -3,12 +3,12 @@ package cchttp
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"net/http"
 
 	"emperror.dev/errors"
 	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccredact"
 	"github.com/sts-solutions/base-code/ccvalidation"
 )
 
@@ -24,12 +24,17 @@ type request struct {
 	response            any
 	responseRawBody     *string
 	responseHeaders     map[string][]string
-	expectedStatusCode  int
+	expectedStatusCodes []int
+	statusTargets       []statusTarget
+	errorResponse       any
+	errorContentType    cccontenttype.ContentType
 	gotStatusCode       *int
 	httpClient          Client
 	httpMethod          string
 	bodyContentType     cccontenttype.ContentType
 	responseContentType cccontenttype.ContentType
+	retryPolicy         *RetryPolicy
+	routeTemplate       string
 
 	shouldVerifyStatusCode      bool
 	shouldUnmarshalResponse     bool
@@ -45,11 +50,16 @@ func (r *request) HTTPRequest() *http.Request {
 }
 
 // Do executes the HTTP request and processes the response
+// With a RetryPolicy idempotent requests are retried, see WithRetry
 func (r *request) Do() error {
 	if err := r.validate(); err != nil {
 		return errors.Wrap(err, "validating request")
 	}
 
+	if r.isRetryable() {
+		return r.doWithRetry()
+	}
+
 	resp, err := r.httpClient.Do(r.httpRequest)
 	if err != nil {
 		return errors.Wrap(err, "executing request")
@@ -71,7 +81,12 @@ func (r *request) getHTTPRequest() (httpReq *http.Request, err error) {
 		return httpReq, errors.Wrap(err, "getting body reader")
 	}
 
-	httpReq, err = http.NewRequestWithContext(r.context, r.httpMethod, r.url, bodyReader)
+	ctx := r.context
+	if r.routeTemplate != "" {
+		ctx = WithRouteTemplate(ctx, r.routeTemplate)
+	}
+
+	httpReq, err = http.NewRequestWithContext(ctx, r.httpMethod, r.url, bodyReader)
 	if err != nil {
 		return httpReq, errors.Wrap(err, "creating body reader")
 	}
@@ -109,16 +124,8 @@ func (r *request) processResponse(resp *http.Response) (err error) {
 		*r.gotStatusCode = resp.StatusCode
 	}
 
-	if r.shouldVerifyStatusCode &&
-		resp.StatusCode != r.expectedStatusCode {
-		err = fmt.Errorf("expected %d, got %d "+
-			"\n\tURL: %s\n\t"+
-			"Request: %v \n\t"+
-			"ResponseBody: %v",
-			r.expectedStatusCode, resp.StatusCode, r.url,
-			r.body, string(bodyRaw))
-
-		return errors.Wrap(err, "unexpected status code")
+	if !r.isAcceptedStatusCode(resp.StatusCode) {
+		return errors.Wrap(r.newStatusError(resp, bodyRaw), "unexpected status code")
 	}
 
 	if r.shouldReturnResponseHeaders {
@@ -131,6 +138,14 @@ func (r *request) processResponse(resp *http.Response) (err error) {
 		*r.responseRawBody = string(bodyRaw)
 	}
 
+	if target, ok := r.statusTarget(resp.StatusCode); ok {
+		err = target.contentType.UnmarshalFunc()(bodyRaw, target.response)
+		if err != nil {
+			return errors.Wrapf(err, "unmarshaling %d response body", resp.StatusCode)
+		}
+		return nil
+	}
+
 	if !r.shouldUnmarshalResponse {
 		return nil
 	}
@@ -143,6 +158,10 @@ func (r *request) processResponse(resp *http.Response) (err error) {
 	return nil
 }
 
+func (r *request) redactedURL() string {
+	return ccredact.String(r.url)
+}
+
 func (r *request) validate() error {
 	result := ccvalidation.Result{}
 
@@ -16,6 +16,8 @@ var (
 	errRequestBuilderMarshalFunctionNotBeenSet   error = errors.New("marshal function has not been set")
 	errRequestBuilderUnmarshalFunctionNotBeenSet error = errors.New("unmarshal function has not been set")
 	errRequestBuilderContextNotBeenSet           error = errors.New("context has not been set")
+	errRequestBuilderRetryMaxAttemptsNotValid    error = errors.New("retry max attempts must be greater than zero")
+	errRequestBuilderStatusRangeNotValid         error = errors.New("status code range is not valid")
 )
 
 type requestBuilder struct {
@@ -26,12 +28,11 @@ type requestBuilder struct {
 func NewRequestBuilder() *requestBuilder {
 	return &requestBuilder{
 		request: &request{
-			context:            context.Background(),
-			expectedStatusCode: -1,
-			headers:            make(map[string]string),
-			responseHeaders:    make(map[string][]string),
-			isBodySet:          false,
-			gotStatusCode:      new(int),
+			context:         context.Background(),
+			headers:         make(map[string]string),
+			responseHeaders: make(map[string][]string),
+			isBodySet:       false,
+			gotStatusCode:   new(int),
 		},
 	}
 }
@@ -83,11 +84,50 @@ func (rb *requestBuilder) WithDefaultHTTPClient() *requestBuilder {
 
 // WithExpectedStatusCode sets the expected status code for the response
 func (rb *requestBuilder) WithExpectedStatusCode(code int) *requestBuilder {
-	rb.request.expectedStatusCode = code
+	return rb.WithExpectedStatusCodes(code)
+}
+
+// WithExpectedStatusCodes sets the expected status codes for the response.
+// Any other status code, not mapped to a response target, makes Do return a
+// *StatusError
+func (rb *requestBuilder) WithExpectedStatusCodes(codes ...int) *requestBuilder {
+	rb.request.expectedStatusCodes = codes
 	rb.request.shouldVerifyStatusCode = true
 	return rb
 }
 
+// WithStatusResponse decodes the body of the responses with the status code
+// into resp, e.g. a NotFound body for 404. The status code is accepted, and
+// when expected status codes are set the status codes not expected nor mapped
+// make Do return a *StatusError
+func (rb *requestBuilder) WithStatusResponse(code int, resp any, contentType cccontenttype.ContentType) *requestBuilder {
+	return rb.WithStatusRangeResponse(code, code, resp, contentType)
+}
+
+// WithStatusRangeResponse decodes the body of the responses with a status code
+// between from and to, both included, into resp, see WithStatusResponse. The
+// first mapping matching the status code is used
+func (rb *requestBuilder) WithStatusRangeResponse(from, to int, resp any,
+	contentType cccontenttype.ContentType) *requestBuilder {
+
+	rb.request.statusTargets = append(rb.request.statusTargets, statusTarget{
+		from:        from,
+		to:          to,
+		response:    resp,
+		contentType: contentType,
+	})
+	return rb
+}
+
+// WithErrorResponse decodes the body of the unexpected status codes into resp,
+// exposed as the Body of the returned *StatusError. By default problem+json
+// bodies are decoded as ProblemDetails and JSON bodies as ErrorResponse
+func (rb *requestBuilder) WithErrorResponse(resp any, contentType cccontenttype.ContentType) *requestBuilder {
+	rb.request.errorResponse = resp
+	rb.request.errorContentType = contentType
+	return rb
+}
+
 // WithStatusCode gets the status code for the response
 func (rb *requestBuilder) WithStatusCode(code *int) *requestBuilder {
 	rb.request.gotStatusCode = code
@@ -151,6 +191,23 @@ func (rb *requestBuilder) WithBody(body any, contentType cccontenttype.ContentTy
 	return rb.WithHeader(cccontenttype.Key.String(), contentType.Name())
 }
 
+// WithRouteTemplate sets the route template of the URL, e.g. /orders/{id},
+// used by the instrumented clients to label the metrics and the spans
+func (rb *requestBuilder) WithRouteTemplate(route string) *requestBuilder {
+	rb.request.routeTemplate = route
+	return rb
+}
+
+// WithRetry retries the request with the policy. Only idempotent methods, or
+// requests with an Idempotency-Key header, are retried. Connection errors,
+// 429 and 5xx responses are retried waiting the policy sleep or the longer
+// Retry-After delay, the body is rebuilt on each attempt. When the request
+// fails Do returns a *RetryError reporting the attempts
+func (rb *requestBuilder) WithRetry(policy RetryPolicy) *requestBuilder {
+	rb.request.retryPolicy = &policy
+	return rb
+}
+
 // WithResponse sets the response type and content type
 // The content type is used to determine the unmarshal function to use
 func (rb *requestBuilder) WithResponse(resp any, contentType cccontenttype.ContentType) *requestBuilder {
@@ -200,6 +257,24 @@ func (rb *requestBuilder) validateRequest() error {
 		result.AddError(errRequestBuilderUnmarshalFunctionNotBeenSet)
 	}
 
+	for _, target := range rb.request.statusTargets {
+		if target.from > target.to {
+			result.AddError(errRequestBuilderStatusRangeNotValid)
+		}
+		if target.contentType == nil || target.contentType.UnmarshalFunc() == nil {
+			result.AddError(errRequestBuilderUnmarshalFunctionNotBeenSet)
+		}
+	}
+
+	if rb.request.errorResponse != nil &&
+		(rb.request.errorContentType == nil || rb.request.errorContentType.UnmarshalFunc() == nil) {
+		result.AddError(errRequestBuilderUnmarshalFunctionNotBeenSet)
+	}
+
+	if rb.request.retryPolicy != nil && rb.request.retryPolicy.MaxAttempts <= 0 {
+		result.AddError(errRequestBuilderRetryMaxAttemptsNotValid)
+	}
+
 	if result.IsFailure() {
 		return result
 	}
@@ -0,0 +1,35 @@
+package cchttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+// Response is the body of a successful response, with the validation warnings
+// of the request
+// swagger:model
+type Response[T any] struct {
+	// Data is the response payload
+	Data T `json:"data"`
+	// Warnings are the validation warnings of the request, e.g. "delivery date is a public holiday"
+	Warnings []ccvalidation.FieldError `json:"warnings,omitempty"`
+}
+
+// NewResponse creates a Response with the warnings of the validation result
+func NewResponse[T any](data T, result ccvalidation.Result) Response[T] {
+	return Response[T]{
+		Data:     data,
+		Warnings: result.GetWarnings(),
+	}
+}
+
+// WriteResponse writes the Response of the data and the validation result
+// warnings as JSON with the HTTP code
+func WriteResponse[T any](w http.ResponseWriter, httpCode int, data T, result ccvalidation.Result) error {
+	w.Header().Set(cccontenttype.Key.String(), cccontenttype.ApplicationJSON.Name())
+	w.WriteHeader(httpCode)
+	return json.NewEncoder(w).Encode(NewResponse(data, result))
+}
@@ -0,0 +1,180 @@
+package cchttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/cchttp/cchttpheaders"
+	"github.com/sts-solutions/base-code/ccretry"
+)
+
+// DefaultMaxRetryAfter caps the Retry-After delay of the policies without MaxRetryAfter
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// RetryPolicy configures the retries of a request, see WithRetry
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+	// Sleep is the delay between attempts, a longer Retry-After delay is honoured
+	Sleep time.Duration
+	// MaxRetryAfter caps the Retry-After delay, DefaultMaxRetryAfter when zero
+	MaxRetryAfter time.Duration
+}
+
+// RetryError is returned by Do when a retried request fails, it reports the
+// attempts made and unwraps the error of the last one
+type RetryError struct {
+	Response ccretry.RetryResponse
+	Err      error
+}
+
+// Error returns the number of attempts followed by the error of the last one
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("after %d attempts: %v", e.Response.NumberOfAttempts(), e.Err)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Attempts returns the number of attempts made
+func (e *RetryError) Attempts() int {
+	return e.Response.NumberOfAttempts()
+}
+
+// attemptError is the error of an attempt that can be retried, a connection
+// error or a 429 or 5xx response, waiting the Retry-After delay of the response
+type attemptError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *attemptError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attemptError) Unwrap() error {
+	return e.err
+}
+
+// RetryAfter returns the Retry-After delay of the response, honoured by ccretry
+func (e *attemptError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// isRetryable returns true when the request can be retried: its method is
+// idempotent or it has an Idempotency-Key header
+func (r *request) isRetryable() bool {
+	if r.retryPolicy == nil || r.retryPolicy.MaxAttempts <= 1 {
+		return false
+	}
+
+	switch r.httpMethod {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return r.httpRequest.Header.Get(cchttpheaders.IdempotencyKey.Name()) != ""
+}
+
+// doWithRetry executes the request with ccretry, rebuilding the request body
+// on each attempt. Connection errors, 429 and 5xx responses are retried while
+// attempts are left, the response of the last attempt is processed as usual.
+// The wait between attempts stops when the request context is done
+func (r *request) doWithRetry() error {
+	attempt := 0
+	retry := ccretry.NewRetry(func() error {
+		attempt++
+		return r.attempt(attempt == r.retryPolicy.MaxAttempts)
+	}).
+		WithContext(r.context).
+		WithMaxAttempts(r.retryPolicy.MaxAttempts).
+		WithSleep(r.retryPolicy.Sleep).
+		WithRetryCondition(func(err error) bool {
+			var attemptErr *attemptError
+			return errors.As(err, &attemptErr) && r.context.Err() == nil
+		})
+
+	resp, err := retry.Run()
+	if err != nil {
+		return &RetryError{Response: resp, Err: err}
+	}
+	return nil
+}
+
+func (r *request) attempt(last bool) error {
+	httpReq, err := r.attemptRequest()
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		delay, _ := ccerrors.RetryAfter(err)
+		return &attemptError{err: errors.Wrap(err, "executing request"), retryAfter: r.capRetryAfter(delay)}
+	}
+
+	defer resp.Body.Close()
+
+	if !last && isRetryableStatusCode(resp.StatusCode) && !r.isHandledStatusCode(resp.StatusCode) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return &attemptError{
+			err:        errors.Errorf("retryable status code %d", resp.StatusCode),
+			retryAfter: r.retryAfter(resp.Header.Get(cchttpheaders.RetryAfter.Name())),
+		}
+	}
+
+	if err = r.processResponse(resp); err != nil {
+		return errors.Wrap(err, "decoding response")
+	}
+	return nil
+}
+
+// attemptRequest returns a copy of the built request with a new body
+func (r *request) attemptRequest() (*http.Request, error) {
+	bodyReader, err := r.getBodyReader()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting body reader")
+	}
+
+	httpReq := r.httpRequest.Clone(r.httpRequest.Context())
+	httpReq.Body = io.NopCloser(bodyReader)
+	if bodyReader == http.NoBody {
+		httpReq.Body = http.NoBody
+	}
+
+	return httpReq, nil
+}
+
+func isRetryableStatusCode(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfter parses a Retry-After header value, in seconds or as an HTTP date,
+// capped by the MaxRetryAfter of the policy
+func (r *request) retryAfter(value string) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	}
+	return r.capRetryAfter(delay)
+}
+
+// capRetryAfter caps the delay by the MaxRetryAfter of the policy
+func (r *request) capRetryAfter(delay time.Duration) time.Duration {
+	maxRetryAfter := r.retryPolicy.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	return min(max(delay, 0), maxRetryAfter)
+}
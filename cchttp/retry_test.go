@@ -0,0 +1,144 @@
+package cchttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+	"github.com/sts-solutions/base-code/cchttp/cchttpheaders"
+)
+
+type testRetryServer struct {
+	*httptest.Server
+	calls  atomic.Int32
+	bodies chan string
+}
+
+// newTestRetryServer answers the first failures calls with the status and the
+// Retry-After header, and the next ones with 200
+func newTestRetryServer(t *testing.T, failures int32, status int, retryAfter string) *testRetryServer {
+	s := &testRetryServer{bodies: make(chan string, 10)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.bodies <- string(body)
+
+		if s.calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set(cchttpheaders.RetryAfter.Name(), retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func Test_Request_WithRetry_IdempotencyKey_ShouldResendBody(t *testing.T) {
+	// Arrange
+	server := newTestRetryServer(t, 2, http.StatusServiceUnavailable, "")
+	var resp map[string]string
+
+	req, err := NewRequestBuilder().
+		WithDefaultHTTPClient().
+		WithURL(server.URL).
+		WithHTTPMethod(http.MethodPost).
+		WithHeader(cchttpheaders.IdempotencyKey.Name(), "order-1").
+		WithBody(map[string]string{"name": "order"}, cccontenttype.ApplicationJSON).
+		WithResponse(&resp, cccontenttype.ApplicationJSON).
+		WithExpectedStatusCode(http.StatusOK).
+		WithRetry(RetryPolicy{MaxAttempts: 3}).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), server.calls.Load())
+	for range 3 {
+		assert.JSONEq(t, `{"name":"order"}`, <-server.bodies)
+	}
+	assert.Equal(t, "1", resp["id"])
+}
+
+func Test_Request_WithRetry_NonIdempotentMethod_ShouldNotRetry(t *testing.T) {
+	// Arrange
+	server := newTestRetryServer(t, 1, http.StatusInternalServerError, "")
+
+	req, err := NewRequestBuilder().
+		WithDefaultHTTPClient().
+		WithURL(server.URL).
+		WithHTTPMethod(http.MethodPost).
+		WithExpectedStatusCode(http.StatusOK).
+		WithRetry(RetryPolicy{MaxAttempts: 3}).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), server.calls.Load())
+}
+
+func Test_Request_WithRetry_Throttled_ShouldHonourRetryAfterAndReportAttempts(t *testing.T) {
+	// Arrange
+	server := newTestRetryServer(t, 5, http.StatusTooManyRequests, "1")
+	var statusCode int
+
+	req, err := NewRequestBuilder().
+		WithDefaultHTTPClient().
+		WithURL(server.URL).
+		WithHTTPMethod(http.MethodGet).
+		WithExpectedStatusCode(http.StatusOK).
+		WithStatusCode(&statusCode).
+		WithRetry(RetryPolicy{MaxAttempts: 3, MaxRetryAfter: 20 * time.Millisecond}).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	start := time.Now()
+	err = req.Do()
+
+	var retryErr *RetryError
+	require.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 3, retryErr.Attempts())
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.Equal(t, http.StatusTooManyRequests, statusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func Test_Request_WithRetry_ContextDeadline_ShouldStopWaitingRetryAfter(t *testing.T) {
+	// Arrange
+	server := newTestRetryServer(t, 5, http.StatusServiceUnavailable, "3")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	req, err := NewRequestBuilder().
+		WithContext(ctx).
+		WithDefaultHTTPClient().
+		WithURL(server.URL).
+		WithHTTPMethod(http.MethodGet).
+		WithExpectedStatusCode(http.StatusOK).
+		WithRetry(RetryPolicy{MaxAttempts: 3}).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	start := time.Now()
+	err = req.Do()
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), server.calls.Load())
+}
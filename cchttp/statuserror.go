@@ -0,0 +1,125 @@
+package cchttp
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+)
+
+// StatusError is returned by Do when the response status code is neither
+// expected nor mapped to a response target. It exposes the response status,
+// headers and body, and unwraps the decoded body when it is an error, so
+// errors.As finds a ProblemDetails or an ErrorResponse
+type StatusError struct {
+	// StatusCode is the status code of the response
+	StatusCode int
+	// ExpectedStatusCodes are the status codes expected by the request
+	ExpectedStatusCodes []int
+	// URL is the redacted URL of the request
+	URL string
+	// Header holds the response headers
+	Header http.Header
+	// Body is the decoded response body: the WithErrorResponse target, a
+	// ProblemDetails for application/problem+json bodies or an ErrorResponse
+	// for JSON bodies. It is nil when the body could not be decoded
+	Body any
+	// RawBody is the response body
+	RawBody []byte
+}
+
+// Error returns the expected and the received status codes, followed by the
+// decoded body when it is an error. The bodies are never included
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("expected %v, got %d \n\tURL: %s", e.ExpectedStatusCodes, e.StatusCode, e.URL)
+	if err, ok := e.Body.(error); ok {
+		return msg + ": " + err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the decoded body when it is an error
+func (e *StatusError) Unwrap() error {
+	err, _ := e.Body.(error)
+	return err
+}
+
+// statusTarget is a response target of a range of status codes
+type statusTarget struct {
+	from        int
+	to          int
+	response    any
+	contentType cccontenttype.ContentType
+}
+
+func (t statusTarget) matches(code int) bool {
+	return code >= t.from && code <= t.to
+}
+
+// statusTarget returns the first response target mapped to the status code
+func (r *request) statusTarget(code int) (statusTarget, bool) {
+	for _, target := range r.statusTargets {
+		if target.matches(code) {
+			return target, true
+		}
+	}
+	return statusTarget{}, false
+}
+
+// isAcceptedStatusCode returns true when the status code is expected or mapped
+// to a response target, or when the status code is not verified
+func (r *request) isAcceptedStatusCode(code int) bool {
+	if !r.shouldVerifyStatusCode || slices.Contains(r.expectedStatusCodes, code) {
+		return true
+	}
+	_, ok := r.statusTarget(code)
+	return ok
+}
+
+// isHandledStatusCode returns true when the status code is mapped to a
+// response target, or expected by a request verifying the status codes
+func (r *request) isHandledStatusCode(code int) bool {
+	if _, ok := r.statusTarget(code); ok {
+		return true
+	}
+	return r.shouldVerifyStatusCode && slices.Contains(r.expectedStatusCodes, code)
+}
+
+// newStatusError decodes the error body of the response
+func (r *request) newStatusError(resp *http.Response, bodyRaw []byte) *StatusError {
+	statusErr := &StatusError{
+		StatusCode:          resp.StatusCode,
+		ExpectedStatusCodes: r.expectedStatusCodes,
+		URL:                 r.redactedURL(),
+		Header:              resp.Header,
+		RawBody:             bodyRaw,
+	}
+
+	contentType := resp.Header.Get(cccontenttype.Key.String())
+	switch {
+	case r.errorResponse != nil:
+		if err := r.errorContentType.UnmarshalFunc()(bodyRaw, r.errorResponse); err == nil {
+			statusErr.Body = r.errorResponse
+		}
+	case IsProblemDetailsContentType(contentType):
+		if problem, err := DecodeProblemDetails(bodyRaw); err == nil {
+			statusErr.Body = problem
+		}
+	case isJSONContentType(contentType):
+		var errResp ErrorResponse
+		err := cccontenttype.ApplicationJSON.UnmarshalFunc()(bodyRaw, &errResp)
+		if err == nil && (errResp.Message != "" || errResp.Code != 0 || errResp.DomainError != nil) {
+			errResp.HTTPCode = resp.StatusCode
+			statusErr.Body = errResp
+		}
+	}
+
+	return statusErr
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == cccontenttype.ApplicationJSON.Name()
+}
@@ -0,0 +1,159 @@
+package cchttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/cchttp/cccontenttype"
+)
+
+type testStatusOrder struct {
+	ID string `json:"id"`
+}
+
+type testStatusNotFound struct {
+	Resource string `json:"resource"`
+}
+
+type testStatusFailure struct {
+	Reason string `json:"reason"`
+}
+
+func newTestStatusServer(t *testing.T, status int, contentType string, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(cccontenttype.Key.String(), contentType)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestStatusRequestBuilder(url string) *requestBuilder {
+	return NewRequestBuilder().
+		WithDefaultHTTPClient().
+		WithURL(url).
+		WithHTTPMethod(http.MethodGet)
+}
+
+func Test_Request_StatusResponses_ShouldDecodeMappedTargets(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		body             string
+		expectedOrder    testStatusOrder
+		expectedNotFound testStatusNotFound
+	}{
+		{"ok", http.StatusOK, `{"id":"1"}`, testStatusOrder{ID: "1"}, testStatusNotFound{}},
+		{"not found", http.StatusNotFound, `{"resource":"order"}`, testStatusOrder{}, testStatusNotFound{Resource: "order"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := newTestStatusServer(t, tt.status, cccontenttype.ApplicationJSON.Name(), tt.body)
+			var order testStatusOrder
+			var notFound testStatusNotFound
+
+			req, err := newTestStatusRequestBuilder(server.URL).
+				WithExpectedStatusCodes(http.StatusOK, http.StatusCreated).
+				WithResponse(&order, cccontenttype.ApplicationJSON).
+				WithStatusRangeResponse(http.StatusNotFound, http.StatusGone, &notFound, cccontenttype.ApplicationJSON).
+				Build()
+			require.NoError(t, err)
+
+			// Act
+			err = req.Do()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOrder, order)
+			assert.Equal(t, tt.expectedNotFound, notFound)
+		})
+	}
+}
+
+func Test_Request_UnexpectedProblemDetails_ShouldReturnStatusError(t *testing.T) {
+	// Arrange
+	server := newTestStatusServer(t, http.StatusConflict, cccontenttype.ApplicationProblemJSON.Name(),
+		`{"title":"Conflict","status":409,"detail":"order is closed","secret":"s3cr3t"}`)
+
+	req, err := newTestStatusRequestBuilder(server.URL).
+		WithExpectedStatusCode(http.StatusOK).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusConflict, statusErr.StatusCode)
+	assert.Equal(t, cccontenttype.ApplicationProblemJSON.Name(), statusErr.Header.Get(cccontenttype.Key.String()))
+
+	var problem ProblemDetails
+	require.True(t, errors.As(err, &problem))
+	assert.Equal(t, "order is closed", problem.Detail)
+	assert.Contains(t, err.Error(), "order is closed")
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func Test_Request_WithErrorResponse_ShouldDecodeCallerType(t *testing.T) {
+	// Arrange
+	server := newTestStatusServer(t, http.StatusInternalServerError, cccontenttype.ApplicationJSON.Name(),
+		`{"reason":"database unavailable"}`)
+	var failure testStatusFailure
+
+	req, err := newTestStatusRequestBuilder(server.URL).
+		WithExpectedStatusCode(http.StatusOK).
+		WithErrorResponse(&failure, cccontenttype.ApplicationJSON).
+		Build()
+	require.NoError(t, err)
+
+	// Act
+	err = req.Do()
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, &failure, statusErr.Body)
+	assert.Equal(t, "database unavailable", failure.Reason)
+	assert.Equal(t, []int{http.StatusOK}, statusErr.ExpectedStatusCodes)
+}
+
+func Test_Request_StatusResponseOnly_ShouldAcceptOtherStatusCodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		body             string
+		expectedOrder    testStatusOrder
+		expectedNotFound testStatusNotFound
+	}{
+		{"ok", http.StatusOK, `{"id":"1"}`, testStatusOrder{ID: "1"}, testStatusNotFound{}},
+		{"not found", http.StatusNotFound, `{"resource":"order"}`, testStatusOrder{}, testStatusNotFound{Resource: "order"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := newTestStatusServer(t, tt.status, cccontenttype.ApplicationJSON.Name(), tt.body)
+			var order testStatusOrder
+			var notFound testStatusNotFound
+
+			req, err := newTestStatusRequestBuilder(server.URL).
+				WithResponse(&order, cccontenttype.ApplicationJSON).
+				WithStatusResponse(http.StatusNotFound, &notFound, cccontenttype.ApplicationJSON).
+				Build()
+			require.NoError(t, err)
+
+			// Act
+			err = req.Do()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOrder, order)
+			assert.Equal(t, tt.expectedNotFound, notFound)
+		})
+	}
+}
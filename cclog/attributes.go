@@ -42,9 +42,10 @@ func (attrs *Attributes) Get(fieldKey string) *zapcore.Field {
 }
 
 // MarshalLogObject is an internal function implementing object serialization in zap.
+// The fields are redacted using the ccredact Policy.
 func (attrs *Attributes) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	for i := 0; i < len(attrs.Fields); i++ {
-		attrs.Fields[i].AddTo(enc)
+		redactField(attrs.Fields[i]).AddTo(enc)
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/sts-solutions/base-code/ccerrors"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -222,6 +223,8 @@ func NewField(key string, value any) zapcore.Field {
 		return zap.Time(key, v)
 	case time.Duration:
 		return zap.Duration(key, v)
+	case *ccerrors.MultiError:
+		return NewMultiErrorField(key, v)
 	case error:
 		return zap.Error(v)
 	default:
@@ -0,0 +1,36 @@
+package cclog
+
+import (
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccredact"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type multiErrorEntries []ccerrors.MultiErrorEntry
+
+// MarshalLogArray is an internal function implementing array serialization in zap.
+func (entries multiErrorEntries) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, entry := range entries {
+		e := entry
+		err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(oe zapcore.ObjectEncoder) error {
+			oe.AddString("message", ccredact.String(e.Message))
+			if e.Code != 0 {
+				oe.AddInt("code", e.Code)
+				oe.AddString("name", e.Name)
+			}
+			oe.AddInt("count", e.Count)
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewMultiErrorField creates a field holding the errors collected by the
+// MultiError as a JSON array of {message, code, name, count} objects.
+func NewMultiErrorField(key string, me *ccerrors.MultiError) zapcore.Field {
+	return zap.Array(key, multiErrorEntries(me.Entries()))
+}
@@ -0,0 +1,36 @@
+package cclog
+
+import (
+	"fmt"
+
+	"github.com/sts-solutions/base-code/ccredact"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactField applies the ccredact Policy to the field: fields with a secret key
+// are replaced, strings, errors, stringers and reflected values are redacted
+func redactField(field zapcore.Field) zapcore.Field {
+	if field.Key != "" && ccredact.IsSecretKey(field.Key) {
+		return zap.String(field.Key, ccredact.Redacted)
+	}
+
+	switch field.Type {
+	case zapcore.StringType:
+		return zap.String(field.Key, ccredact.String(field.String))
+	case zapcore.ByteStringType:
+		return zap.ByteString(field.Key, []byte(ccredact.String(string(field.Interface.([]byte)))))
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return zap.NamedError(field.Key, ccredact.Error(err))
+		}
+	case zapcore.StringerType:
+		if stringer, ok := field.Interface.(fmt.Stringer); ok {
+			return zap.String(field.Key, ccredact.String(stringer.String()))
+		}
+	case zapcore.ReflectType:
+		return zap.Any(field.Key, ccredact.Value(field.Key, field.Interface))
+	}
+
+	return field
+}
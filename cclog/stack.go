@@ -0,0 +1,43 @@
+package cclog
+
+import (
+	"github.com/sts-solutions/base-code/ccerrors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// StackKey is the default key used for stack trace fields
+const StackKey = "stack"
+
+type stackFrames []ccerrors.Frame
+
+// MarshalLogArray is an internal function implementing array serialization in zap.
+func (frames stackFrames) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, frame := range frames {
+		f := frame
+		err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(oe zapcore.ObjectEncoder) error {
+			oe.AddString("function", f.Function)
+			oe.AddString("file", f.File)
+			oe.AddInt("line", f.Line)
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewStackField creates a field holding the stack trace frames of the error as
+// a JSON array of {function, file, line} objects.
+// The stack trace is rendered with the given ccerrors.StackTrace, so its
+// excluded patterns are applied
+func NewStackField(key string, err error, st ccerrors.StackTrace) zapcore.Field {
+	return NewFramesField(key, st.GetFrames(err))
+}
+
+// NewFramesField creates a field holding the stack trace frames as a JSON array
+// of {function, file, line} objects.
+func NewFramesField(key string, frames []ccerrors.Frame) zapcore.Field {
+	return zap.Array(key, stackFrames(frames))
+}
@@ -0,0 +1,114 @@
+package cclogger
+
+import (
+	"context"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+const (
+	ErrorKey            = "error"
+	ErrorFingerprintKey = "error_fingerprint"
+)
+
+// NewErrorFields returns the log fields for the error and its fingerprint
+func NewErrorFields(err error) []LogField {
+	return []LogField{
+		{Key: ErrorKey, Value: err},
+		{Key: ErrorFingerprintKey, Value: ccerrors.Fingerprint(err)},
+	}
+}
+
+// rateLimitedLogger drops the warn and error entries whose error fingerprint
+// has reached the limiter limit in the current window. The fields added with
+// WithField are kept until an entry is logged, so the ones of a dropped entry
+// never reach the wrapped logger
+type rateLimitedLogger struct {
+	logger  Logger
+	limiter *ccerrors.FingerprintLimiter
+	fields  []LogField
+}
+
+// NewRateLimitedLogger wraps the logger so warn and error entries holding an
+// error field are only logged while their fingerprint is allowed by the limiter.
+// The fingerprint is added to the entries that do not have it yet
+func NewRateLimitedLogger(logger Logger, limiter *ccerrors.FingerprintLimiter) Logger {
+	return &rateLimitedLogger{
+		logger:  logger,
+		limiter: limiter,
+	}
+}
+
+// WithField returns a logger with the field, sharing the wrapped logger and the limiter
+func (l *rateLimitedLogger) WithField(name string, value interface{}) Logger {
+	fields := make([]LogField, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+
+	return &rateLimitedLogger{
+		logger:  l.logger,
+		limiter: l.limiter,
+		fields:  append(fields, LogField{Key: name, Value: value}),
+	}
+}
+
+func (l *rateLimitedLogger) Debug(ctx context.Context, msg string, logFields ...LogField) {
+	l.withFields().Debug(ctx, msg, logFields...)
+}
+
+func (l *rateLimitedLogger) Info(ctx context.Context, msg string, logFields ...LogField) {
+	l.withFields().Info(ctx, msg, logFields...)
+}
+
+func (l *rateLimitedLogger) Warn(ctx context.Context, msg string, logFields ...LogField) {
+	logFields, allowed := l.allow(logFields)
+	if allowed {
+		l.withFields().Warn(ctx, msg, logFields...)
+	}
+}
+
+func (l *rateLimitedLogger) Error(ctx context.Context, msg string, logFields ...LogField) {
+	logFields, allowed := l.allow(logFields)
+	if allowed {
+		l.withFields().Error(ctx, msg, logFields...)
+	}
+}
+
+func (l *rateLimitedLogger) Fatal(ctx context.Context, msg string, logFields ...LogField) {
+	l.withFields().Fatal(ctx, msg, logFields...)
+}
+
+// withFields returns the wrapped logger with the fields added with WithField
+func (l *rateLimitedLogger) withFields() Logger {
+	logger := l.logger
+	for _, field := range l.fields {
+		logger = logger.WithField(field.Key, field.Value)
+	}
+	return logger
+}
+
+func (l *rateLimitedLogger) allow(logFields []LogField) ([]LogField, bool) {
+	var (
+		err         error
+		fingerprint string
+	)
+
+	for _, lf := range logFields {
+		if lf.Key == ErrorFingerprintKey {
+			fingerprint, _ = lf.Value.(string)
+		}
+		if e, ok := lf.Value.(error); ok && err == nil {
+			err = e
+		}
+	}
+
+	if err == nil && fingerprint == "" {
+		return logFields, true
+	}
+
+	if fingerprint == "" {
+		fingerprint = ccerrors.Fingerprint(err)
+		logFields = append(logFields, LogField{Key: ErrorFingerprintKey, Value: fingerprint})
+	}
+
+	return logFields, l.limiter.AllowFingerprint(fingerprint)
+}
@@ -0,0 +1,49 @@
+package cclogger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+// testLogger records the fields of the logged entries in the shared entries
+type testLogger struct {
+	fields  []LogField
+	entries *[][]LogField
+}
+
+func (l *testLogger) WithField(name string, value interface{}) Logger {
+	fields := append(append([]LogField{}, l.fields...), LogField{Key: name, Value: value})
+	return &testLogger{fields: fields, entries: l.entries}
+}
+
+func (l *testLogger) log(logFields []LogField) {
+	*l.entries = append(*l.entries, append(append([]LogField{}, l.fields...), logFields...))
+}
+
+func (l *testLogger) Debug(ctx context.Context, msg string, logFields ...LogField) { l.log(logFields) }
+func (l *testLogger) Info(ctx context.Context, msg string, logFields ...LogField)  { l.log(logFields) }
+func (l *testLogger) Warn(ctx context.Context, msg string, logFields ...LogField)  { l.log(logFields) }
+func (l *testLogger) Error(ctx context.Context, msg string, logFields ...LogField) { l.log(logFields) }
+func (l *testLogger) Fatal(ctx context.Context, msg string, logFields ...LogField) { l.log(logFields) }
+
+func Test_RateLimitedLogger_WithField_ShouldNotLeakFields(t *testing.T) {
+	// Arrange
+	var entries [][]LogField
+	logger := NewRateLimitedLogger(&testLogger{entries: &entries}, ccerrors.NewFingerprintLimiter(1, time.Minute))
+	errBoom := errors.New("boom")
+	ctx := context.Background()
+
+	// Act
+	logger.WithField("order_id", "1").Error(ctx, "failed", NewErrorFields(errBoom)...)
+	logger.WithField("order_id", "2").Error(ctx, "failed", NewErrorFields(errBoom)...)
+	logger.WithField("user_id", "3").Info(ctx, "done")
+
+	assert.Len(t, entries, 2)
+	assert.Contains(t, entries[0], LogField{Key: "order_id", Value: "1"})
+	assert.Equal(t, []LogField{{Key: "user_id", Value: "3"}}, entries[1])
+}
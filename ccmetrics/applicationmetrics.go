@@ -5,3 +5,9 @@ type ApplicationMetricsHandler interface {
 	ErrorInc(processName string)
 	PanicInc(correlationID string)
 }
+
+// ErrorFingerprintMetricsHandler counts the errors by their fingerprint, a
+// metric label bounded by a FingerprintLabeler so alerting can group by it
+type ErrorFingerprintMetricsHandler interface {
+	ErrorFingerprintInc(processName string, err error)
+}
@@ -6,9 +6,11 @@ import (
 )
 
 type ApplicationMetricsHandler struct {
-	verion       *prometheus.GaugeVec
-	errorCounter *prometheus.CounterVec
-	panicCounter *prometheus.CounterVec
+	verion                  *prometheus.GaugeVec
+	errorCounter            *prometheus.CounterVec
+	errorFingerprintCounter *prometheus.CounterVec
+	panicCounter            *prometheus.CounterVec
+	fingerprintLabeler      *ccmetrics.FingerprintLabeler
 }
 
 func NewApplicationMetricsHandler(namespace string) ccmetrics.ApplicationMetricsHandler {
@@ -32,6 +34,16 @@ func NewApplicationMetricsHandler(namespace string) ccmetrics.ApplicationMetrics
 		[]string{"process_name"},
 	)
 
+	amh.errorFingerprintCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "error_fingerprints_total",
+			Help:      "Total number of errors by fingerprint",
+		},
+		[]string{"process_name", "fingerprint"},
+	)
+	amh.fingerprintLabeler = ccmetrics.NewFingerprintLabeler(ccmetrics.DefaultMaxFingerprintLabels)
+
 	amh.panicCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -52,6 +64,12 @@ func (amh *ApplicationMetricsHandler) ErrorInc(processName string) {
 	amh.errorCounter.WithLabelValues(processName).Inc()
 }
 
+// ErrorFingerprintInc implements ccmetrics.ErrorFingerprintMetricsHandler, the
+// fingerprint label is bounded to ccmetrics.DefaultMaxFingerprintLabels values
+func (amh *ApplicationMetricsHandler) ErrorFingerprintInc(processName string, err error) {
+	amh.errorFingerprintCounter.WithLabelValues(processName, amh.fingerprintLabeler.Label(err)).Inc()
+}
+
 func (amh *ApplicationMetricsHandler) PanicInc(correlationID string) {
 	amh.panicCounter.WithLabelValues(correlationID).Inc()
 }
@@ -14,3 +14,9 @@ type DownstreamGrpcCallsMetricsHandler interface {
 	GrpcErrorInc(recipient, method, code string)
 	Interceptor() grpc.UnaryClientInterceptor
 }
+
+// CircuitBreakerMetricsHandler records the state changes of the circuit
+// breakers of the downstream calls, e.g. from "closed" to "open"
+type CircuitBreakerMetricsHandler interface {
+	CircuitBreakerStateChange(recipient, from, to string)
+}
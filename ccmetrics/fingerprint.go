@@ -0,0 +1,54 @@
+package ccmetrics
+
+import (
+	"sync"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+const (
+	// OtherFingerprintLabel is the label value used once the limit of distinct fingerprints is reached
+	OtherFingerprintLabel = "other"
+	// DefaultMaxFingerprintLabels is the number of distinct fingerprint label values of the metric handlers
+	DefaultMaxFingerprintLabels = 100
+)
+
+// FingerprintLabeler returns error fingerprints to be used as metric label
+// values, bounding the number of distinct values to keep the cardinality low
+type FingerprintLabeler struct {
+	mu           sync.Mutex
+	maxValues    int
+	fingerprints map[string]struct{}
+}
+
+// NewFingerprintLabeler creates a FingerprintLabeler allowing maxValues distinct fingerprints
+func NewFingerprintLabeler(maxValues int) *FingerprintLabeler {
+	return &FingerprintLabeler{
+		maxValues:    maxValues,
+		fingerprints: make(map[string]struct{}),
+	}
+}
+
+// Label returns the fingerprint of the error, or OtherFingerprintLabel once
+// maxValues distinct fingerprints have been returned
+func (fl *FingerprintLabeler) Label(err error) string {
+	return fl.LabelFingerprint(ccerrors.Fingerprint(err))
+}
+
+// LabelFingerprint returns the fingerprint, or OtherFingerprintLabel once
+// maxValues distinct fingerprints have been returned
+func (fl *FingerprintLabeler) LabelFingerprint(fingerprint string) string {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	if _, ok := fl.fingerprints[fingerprint]; ok {
+		return fingerprint
+	}
+
+	if len(fl.fingerprints) >= fl.maxValues {
+		return OtherFingerprintLabel
+	}
+
+	fl.fingerprints[fingerprint] = struct{}{}
+	return fingerprint
+}
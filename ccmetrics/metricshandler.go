@@ -1,9 +1,9 @@
 package ccmetrics
 
 import (
-	"emperror.dev/errors"
-	"github.com/sts-solutions/base-code/ccerrors/withinnererr"
 	"time"
+
+	"emperror.dev/errors"
 )
 
 // MetricsHandler is the interface that implements the functions to register metrics
@@ -31,11 +31,15 @@ func Register(mHandler MetricsHandler, obj any) {
 			traceable.RegisterMetric(mHandler)
 			registed = true
 		}
-		if withInnerErr, ok := obj.(withinnererr.WithInnerErr); ok {
-			obj = errors.Cause(withInnerErr.GetInnerErr())
-			continue
+		err, ok := obj.(error)
+		if !ok {
+			break
+		}
+		innerErr := errors.Unwrap(err)
+		if innerErr == nil {
+			break
 		}
-		break
+		obj = innerErr
 	}
 
 	if !registed {
@@ -0,0 +1,48 @@
+package ccmsgqueue
+
+import (
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+const (
+	// DomainErrorHeader is the message header holding an encoded DomainError
+	DomainErrorHeader = "X-Domain-Error"
+)
+
+// SetDomainErrorHeader encodes the nearest DomainError in the error chain into
+// the DomainErrorHeader of the given headers
+func SetDomainErrorHeader(headers map[string][]string, err error) error {
+	data, encErr := ccerrors.EncodeDomainError(err, false)
+	if encErr != nil {
+		return encErr
+	}
+
+	headers[DomainErrorHeader] = []string{string(data)}
+	return nil
+}
+
+// GetDomainErrorHeader rebuilds the DomainError stored in the DomainErrorHeader.
+// It returns false when the header is not set
+func GetDomainErrorHeader(headers map[string][]string) (*ccerrors.DomainError, bool, error) {
+	values := headers[DomainErrorHeader]
+	if len(values) == 0 || values[0] == "" {
+		return nil, false, nil
+	}
+
+	domainErr, err := ccerrors.DecodeDomainError([]byte(values[0]))
+	if err != nil {
+		return nil, true, err
+	}
+	return domainErr, true, nil
+}
+
+// EncodeDomainErrorData encodes the nearest DomainError in the error chain,
+// including its stack trace when withStack is true, to be used as message data
+func EncodeDomainErrorData(err error, withStack bool) ([]byte, error) {
+	return ccerrors.EncodeDomainError(err, withStack)
+}
+
+// DecodeDomainErrorData rebuilds the DomainError encoded in the message data
+func DecodeDomainErrorData(data []byte) (*ccerrors.DomainError, error) {
+	return ccerrors.DecodeDomainError(data)
+}
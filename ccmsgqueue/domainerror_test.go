@@ -0,0 +1,66 @@
+package ccmsgqueue
+
+import (
+	"errors"
+	"testing"
+
+	emperrors "emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+var errTestStockUnavailableCode = ccerrors.NewExternalCallErrorCode(5, "stock_unavailable")
+
+func Test_DomainErrorHeader_ShouldRoundTrip(t *testing.T) {
+	// Arrange
+	sent := ccerrors.NewDomainError(errors.New("connection refused"), errTestStockUnavailableCode)
+	sent.SetDetail("sku", "ABC")
+	headers := map[string][]string{}
+
+	// Act
+	err := SetDomainErrorHeader(headers, emperrors.Wrap(sent, "reserving stock"))
+	require.NoError(t, err)
+	got, ok, err := GetDomainErrorHeader(headers)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, errors.Is(got, errTestStockUnavailableCode))
+	assert.Equal(t, map[string]interface{}{"sku": "ABC"}, got.Detail())
+}
+
+func Test_SetDomainErrorHeader_NoDomainError_ShouldFail(t *testing.T) {
+	// Arrange
+	headers := map[string][]string{}
+
+	// Act
+	err := SetDomainErrorHeader(headers, errors.New("plain"))
+
+	assert.Error(t, err)
+	assert.NotContains(t, headers, DomainErrorHeader)
+}
+
+func Test_GetDomainErrorHeader_MissingOrInvalidHeader(t *testing.T) {
+	// Act
+	_, missing, missingErr := GetDomainErrorHeader(map[string][]string{})
+	_, invalid, invalidErr := GetDomainErrorHeader(map[string][]string{DomainErrorHeader: {"not json"}})
+
+	assert.False(t, missing)
+	assert.NoError(t, missingErr)
+	assert.True(t, invalid)
+	assert.Error(t, invalidErr)
+}
+
+func Test_DomainErrorData_ShouldRoundTripWithStack(t *testing.T) {
+	// Arrange
+	sent := ccerrors.NewDomainError(errors.New("connection refused"), errTestStockUnavailableCode)
+
+	// Act
+	data, err := EncodeDomainErrorData(sent, true)
+	require.NoError(t, err)
+	got, err := DecodeDomainErrorData(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, sent.Error(), got.Error())
+	assert.Equal(t, sent.StackTrace(), got.StackTrace())
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccerrors/ccrecover"
 	"github.com/sts-solutions/base-code/ccmetrics"
 	"github.com/sts-solutions/base-code/ccmiddlewares/cccorrelation"
 	"github.com/sts-solutions/base-code/ccotel/ccotelnats"
@@ -24,6 +25,7 @@ type JetStreamContext struct {
 type NatsConsumer struct {
 	SubDefinition    []SubscriptionDefinition
 	natsMetricts     ccmetrics.MetricsNats
+	recoverer        *ccrecover.Recoverer
 	js               nats.JetStreamContext
 	wg               *sync.WaitGroup
 	stopSubcriptions func()
@@ -43,9 +45,17 @@ func New(js nats.JetStreamContext, natsMetricts ccmetrics.MetricsNats) *NatsCons
 		wg:            &sync.WaitGroup{},
 		SubDefinition: make([]SubscriptionDefinition, 0),
 		natsMetricts:  natsMetricts,
+		recoverer:     ccrecover.New(nil, nil),
 	}
 }
 
+// WithRecoverer sets the Recoverer of the handler panics, the one shared with
+// the HTTP and gRPC handlers so the panics are logged and reported alike
+func (c *NatsConsumer) WithRecoverer(recoverer *ccrecover.Recoverer) *NatsConsumer {
+	c.recoverer = recoverer
+	return c
+}
+
 func (c *NatsConsumer) Start(ctx context.Context, shutdownCallBack func()) {
 	ctx, c.stopSubcriptions = context.WithCancel(ctx)
 	for _, subDef := range c.SubDefinition {
@@ -127,45 +137,14 @@ func (c *NatsConsumer) subcribe(ctx context.Context, subDef SubscriptionDefiniti
 
 					var handlerError error
 					defer func() {
-						if err := recover(); err != nil {
-							handlerError = ccerrors.NewDebugTrackError("panic caught", nil, nil)
-						}
-
-						if handlerError != nil {
-							if errors.Is(handlerError, &ccerrors.TransientError{}) {
-								log.Logger().WithFields(logrus.Fields{
-									"msg_headers": msg.Header,
-									"msg_subject": msg.Subject,
-									"msg_data":    string(msg.Data),
-								}).Errorf("%v", handlerError)
-								log.Logger().Info("nats err executin a transaction")
-
-								if err := msg.Nak(); err != nil {
-									log.Logger().
-										Errorf("%v", err)
-									log.Logger().Info("nats error nak-ing msg")
-								}
-
-								return
-							}
-
-							log.Logger().WithFields(logrus.Fields{
-								"msg_headers": msg.Header,
-								"msg_subject": msg.Subject,
-								"msg_data":    string(msg.Data),
-							}).Errorf("%v", handlerError)
-							log.Logger().Info("nats error handling msg")
-						}
-
-						if err := msg.Ack(); err != nil {
-							log.Logger().WithFields(logrus.Fields{
-								"msg_headers": msg.Header,
-								"msg_subject": msg.Subject,
-								"msg_data":    string(msg.Data),
-							}).Errorf("%v", handlerError)
-							log.Logger().Info("nats err acking message")
-						}
+						settleMessage(msg, handlerError, logrus.Fields{
+							"msg_headers": msg.Header,
+							"msg_subject": msg.Subject,
+							"msg_data":    string(msg.Data),
+						})
 					}()
+					defer c.recoverer.RecoverTo(ctx, &handlerError)
+
 					handlerError = subDef.Handler(ctx, subDef.Subject, msg.Header, msg.Data)
 				}(ctx, m)
 
@@ -174,3 +153,58 @@ func (c *NatsConsumer) subcribe(ctx context.Context, subDef SubscriptionDefiniti
 		}
 	}
 }
+
+// ackMessage is the acknowledgement API of the JetStream messages
+type ackMessage interface {
+	Ack(opts ...nats.AckOpt) error
+	Nak(opts ...nats.AckOpt) error
+	NakWithDelay(delay time.Duration, opts ...nats.AckOpt) error
+	Term(opts ...nats.AckOpt) error
+}
+
+// settleMessage acks the message when it was handled. Otherwise transient and
+// cancelled errors nak it, throttled errors nak it with their retry after
+// delay, permanent errors terminate it and unclassified errors ack it
+func settleMessage(msg ackMessage, handlerError error, msgFields logrus.Fields) {
+	if handlerError != nil {
+		errClass := ccerrors.Classify(handlerError)
+		log.Logger().WithFields(msgFields).
+			WithField("error_class", errClass.String()).
+			Errorf("%v", handlerError)
+
+		switch errClass {
+		case ccerrors.ClassTransient, ccerrors.ClassCancelled:
+			log.Logger().Info("nats transient error handling msg, nak-ing to retry later")
+			if err := msg.Nak(); err != nil {
+				log.Logger().
+					Errorf("%v", err)
+				log.Logger().Info("nats error nak-ing msg")
+			}
+			return
+		case ccerrors.ClassThrottled:
+			retryAfter, _ := ccerrors.RetryAfter(handlerError)
+			log.Logger().Info("nats throttled error handling msg, nak-ing to retry later")
+			if err := msg.NakWithDelay(retryAfter); err != nil {
+				log.Logger().
+					Errorf("%v", err)
+				log.Logger().Info("nats error nak-ing msg")
+			}
+			return
+		case ccerrors.ClassPermanent:
+			log.Logger().Info("nats permanent error handling msg, terminating msg")
+			if err := msg.Term(); err != nil {
+				log.Logger().
+					Errorf("%v", err)
+				log.Logger().Info("nats error terminating msg")
+			}
+			return
+		}
+
+		log.Logger().Info("nats error handling msg")
+	}
+
+	if err := msg.Ack(); err != nil {
+		log.Logger().WithFields(msgFields).Errorf("%v", handlerError)
+		log.Logger().Info("nats err acking message")
+	}
+}
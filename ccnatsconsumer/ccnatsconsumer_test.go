@@ -0,0 +1,67 @@
+package ccnatsconsumer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+// testMessage records the acknowledgements of a message
+type testMessage struct {
+	acks     []string
+	nakDelay time.Duration
+}
+
+func (m *testMessage) Ack(opts ...nats.AckOpt) error {
+	m.acks = append(m.acks, "ack")
+	return nil
+}
+
+func (m *testMessage) Nak(opts ...nats.AckOpt) error {
+	m.acks = append(m.acks, "nak")
+	return nil
+}
+
+func (m *testMessage) NakWithDelay(delay time.Duration, opts ...nats.AckOpt) error {
+	m.acks = append(m.acks, "nak_with_delay")
+	m.nakDelay = delay
+	return nil
+}
+
+func (m *testMessage) Term(opts ...nats.AckOpt) error {
+	m.acks = append(m.acks, "term")
+	return nil
+}
+
+func Test_SettleMessage_ShouldAcknowledgeByErrorClass(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedAcks  []string
+		expectedDelay time.Duration
+	}{
+		{"handled", nil, []string{"ack"}, 0},
+		{"transient", ccerrors.NewTransientError("timeout", errors.New("dial tcp"), nil), []string{"nak"}, 0},
+		{"throttled", ccerrors.NewThrottledError("rate limited", nil, 3*time.Second, nil), []string{"nak_with_delay"}, 3 * time.Second},
+		{"permanent", ccerrors.NewPermanentError("invalid payload", nil, nil), []string{"term"}, 0},
+		{"unclassified", errors.New("boom"), []string{"ack"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			msg := &testMessage{}
+
+			// Act
+			settleMessage(msg, tt.err, logrus.Fields{"msg_subject": "orders.created"})
+
+			assert.Equal(t, tt.expectedAcks, msg.acks)
+			assert.Equal(t, tt.expectedDelay, msg.nakDelay)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package ccredacttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// AssertNeverContains asserts that none of the secrets appears in the output.
+// Strings, byte slices and errors are checked as is, other values are checked
+// in their %+v and JSON renderings
+func AssertNeverContains(t testing.TB, output any, secrets ...string) bool {
+	t.Helper()
+
+	renderings := render(output)
+
+	ok := true
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		for _, rendering := range renderings {
+			if strings.Contains(rendering, secret) {
+				t.Errorf("secret %q found in output:\n%s", secret, rendering)
+				ok = false
+				break
+			}
+		}
+	}
+	return ok
+}
+
+func render(output any) []string {
+	switch v := output.(type) {
+	case string:
+		return []string{v}
+	case []byte:
+		return []string{string(v)}
+	case error:
+		return []string{v.Error(), fmt.Sprintf("%+v", v)}
+	case fmt.Stringer:
+		return []string{v.String()}
+	}
+
+	renderings := []string{fmt.Sprintf("%+v", output)}
+	if data, err := json.Marshal(output); err == nil {
+		renderings = append(renderings, string(data))
+	}
+	return renderings
+}
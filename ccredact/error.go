@@ -0,0 +1,31 @@
+package ccredact
+
+import "fmt"
+
+// Error returns an error rendering the error with its secrets replaced using
+// the current Policy, including its verbose %+v format. The error is still
+// matched by errors.Is and errors.As
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return redactedError{err: err}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter so the verbose format of the error, e.g. its
+// stack trace, is also redacted
+func (e redactedError) Format(s fmt.State, verb rune) {
+	fmt.Fprint(s, String(fmt.Sprintf(fmt.FormatString(s, verb), e.err)))
+}
@@ -0,0 +1,108 @@
+package ccredact_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccdatabase/postgres"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/cchttp"
+	"github.com/sts-solutions/base-code/cclog"
+	"github.com/sts-solutions/base-code/ccredact"
+	"github.com/sts-solutions/base-code/ccredact/ccredacttest"
+)
+
+const testSecret = "s3cr3t-Value"
+
+type testCredentials struct {
+	User   string `json:"user"`
+	APIKey string `json:"api_key"`
+	Pin    string `json:"pin" redact:"true"`
+}
+
+func Test_Policy_String_ValuePatterns_ShouldRedactSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"url user info", "postgresql://app:" + testSecret + "@db:5432/orders", "postgresql://app:[REDACTED]@db:5432/orders"},
+		{"query string", "https://api/orders?access_token=" + testSecret + "&page=2", "https://api/orders?access_token=[REDACTED]&page=2"},
+		{"json member", `{"user":"app","password":"` + testSecret + `"}`, `{"user":"app","password":"[REDACTED]"}`},
+		{"bearer", "Authorization: Bearer " + testSecret, "Authorization: Bearer [REDACTED]"},
+		{"no secret", "order 42 not found", "order 42 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := ccredact.String(tt.input)
+
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_Policy_Value_Struct_ShouldRedactTaggedAndSecretFields(t *testing.T) {
+	// Arrange
+	creds := testCredentials{User: "app", APIKey: testSecret, Pin: testSecret}
+
+	// Act
+	got := ccredact.Value("client", &creds)
+
+	assert.Equal(t, map[string]any{"user": "app", "api_key": ccredact.Redacted, "pin": ccredact.Redacted}, got)
+}
+
+func Test_Redaction_Outputs_ShouldNeverContainSecret(t *testing.T) {
+	// Arrange
+	domainErr := ccerrors.NewDomainError(
+		fmt.Errorf("connecting to postgresql://app:%s@db:5432/orders", testSecret),
+		ccerrors.NewPersistenceErrorCode(1, "db_failure"))
+	domainErr.SetDetail("token", testSecret)
+	domainErr.SetDetail("client", testCredentials{User: "app", Pin: testSecret})
+
+	logger, output := cclog.NewTestLogger()
+	dbConnection := postgres.NewDBConnectionParams("db", "orders", "disable", 5432, "app", testSecret)
+
+	// Act
+	logger.Error(context.Background(), "query failed",
+		cclog.NewField("error", domainErr),
+		cclog.NewField("password", testSecret),
+		cclog.NewField("connection", dbConnection.ConnectionString()))
+	errResp := cchttp.FrontError(domainErr)
+	encoded, err := json.Marshal(ccerrors.NewDomainErrorJSON(domainErr))
+
+	assert.NoError(t, err)
+	ccredacttest.AssertNeverContains(t, string(encoded), testSecret)
+
+	ccredacttest.AssertNeverContains(t, domainErr.RedactedDetail(), testSecret)
+	assert.Contains(t, output.String(), ccredact.Redacted)
+	ccredacttest.AssertNeverContains(t, output.String(), testSecret)
+	ccredacttest.AssertNeverContains(t, errResp.Message, testSecret)
+	ccredacttest.AssertNeverContains(t, cchttp.NewProblemDetails(errResp), testSecret)
+	ccredacttest.AssertNeverContains(t, dbConnection, testSecret)
+	ccredacttest.AssertNeverContains(t, ccredact.Error(errors.Join(domainErr)), testSecret)
+}
+
+func Test_DomainError_SetDetail_ShouldKeepOriginalValues(t *testing.T) {
+	// Arrange
+	domainErr := ccerrors.NewDomainError(nil, ccerrors.NewPersistenceErrorCode(1, "db_failure"))
+	cause := errors.New("timeout")
+
+	// Act
+	domainErr.SetDetail("skus", []string{"A", "B"})
+	domainErr.SetDetail("cause", cause)
+	domainErr.SetDetail("labels", map[string]string{"env": "prod"})
+	domainErr.SetDetail("token", testSecret)
+
+	skus, ok := domainErr.Detail()["skus"].([]string)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"A", "B"}, skus)
+	assert.Equal(t, cause, domainErr.Detail()["cause"])
+	assert.Equal(t, map[string]string{"env": "prod"}, domainErr.Detail()["labels"])
+	assert.Equal(t, ccredact.Redacted, domainErr.RedactedDetail()["token"])
+}
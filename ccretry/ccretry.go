@@ -1,14 +1,18 @@
 package ccretry
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"time"
 
 	"emperror.dev/errors"
+	"github.com/sts-solutions/base-code/ccerrors"
 )
 
 type Retry struct {
+	ctx              context.Context
 	maxAttempts      int
 	sleep            time.Duration
 	fn               func() error
@@ -46,15 +50,23 @@ func (r RetryResponse) NumberOfAttempts() int {
 	return len(r.attempts)
 }
 
+// DefaultRetryCondition retries every error except the ones classified by
+// ccerrors.Classify as permanent or cancelled
+func DefaultRetryCondition(err error) bool {
+	return ccerrors.IsRetryable(err)
+}
+
 // NewRetry creates a new Retry instance with 1 attempt and 0ms sleep
+// Permanent and cancelled errors are not retried, see DefaultRetryCondition
 func NewRetry(fn func() error) *Retry {
 	return &Retry{
+		ctx:              context.Background(),
 		fn:               fn,
 		maxAttempts:      1,
 		sleep:            0,
 		errorsToRetry:    make(map[string]struct{}),
 		errorsToNotRetry: make(map[string]struct{}),
-		retryCondition:   func(err error) bool { return true },
+		retryCondition:   DefaultRetryCondition,
 	}
 }
 
@@ -70,6 +82,13 @@ func (r *Retry) WithSleep(sleep time.Duration) *Retry {
 	return r
 }
 
+// WithContext sets the context of the retries, the wait between attempts stops
+// when the context is done
+func (r *Retry) WithContext(ctx context.Context) *Retry {
+	r.ctx = ctx
+	return r
+}
+
 // WithRetryCondition sets a custom condition function to determine if an error should be retried
 func (r *Retry) WithRetryCondition(fn func(error) bool) *Retry {
 	r.retryCondition = fn
@@ -94,7 +113,9 @@ func (r *Retry) WithRetryableErrorTypes(types ...any) *Retry {
 	return r
 }
 
-// Run runs the function with the configured max attempts and sleep duration
+// Run runs the function with the configured max attempts and sleep duration.
+// When the context is done while waiting, the context error is returned
+// wrapping the error of the last attempt
 func (r *Retry) Run() (resp RetryResponse, err error) {
 	if r.maxAttempts <= 0 {
 		return resp, errors.New("max attempts must be greater than 0")
@@ -123,22 +144,51 @@ func (r *Retry) Run() (resp RetryResponse, err error) {
 		}
 
 		if attempt < r.maxAttempts-1 {
-			time.Sleep(r.sleep)
+			if ctxErr := r.wait(r.sleepFor(err)); ctxErr != nil {
+				return resp, fmt.Errorf("%w: %w", ctxErr, err)
+			}
 		}
 	}
 
 	return resp, err
 }
 
+// wait sleeps the duration, returning the context error when the context is
+// done before
+func (r *Retry) wait(d time.Duration) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// sleepFor returns the sleep duration before the next attempt, waiting at
+// least the retry after delay of throttled errors
+func (r *Retry) sleepFor(err error) time.Duration {
+	if retryAfter, ok := ccerrors.RetryAfter(err); ok && retryAfter > r.sleep {
+		return retryAfter
+	}
+	return r.sleep
+}
+
 func (r *Retry) shouldRetry(err error) bool {
 	if r.retryCondition != nil && !r.retryCondition(err) {
 		return false
 	}
 
-	errType := reflect.TypeOf(errors.Cause(err)).String()
+	errTypes := errorTypes(err)
 
 	// Check if error is in the do-not-retry list
-	if len(r.errorsToNotRetry) > 0 {
+	for _, errType := range errTypes {
 		if _, exists := r.errorsToNotRetry[errType]; exists {
 			return false
 		}
@@ -150,6 +200,22 @@ func (r *Retry) shouldRetry(err error) bool {
 	}
 
 	// Only retry if error is in the retry list
-	_, needsRetry := r.errorsToRetry[errType]
-	return needsRetry
+	for _, errType := range errTypes {
+		if _, needsRetry := r.errorsToRetry[errType]; needsRetry {
+			return true
+		}
+	}
+	return false
+}
+
+// errorTypes returns the type names of every error of the Unwrap chain, so the
+// configured types match wrapper errors like ccerrors.TransientError as well
+// as the errors they wrap
+func errorTypes(err error) []string {
+	var types []string
+	for err != nil {
+		types = append(types, reflect.TypeOf(err).String())
+		err = errors.Unwrap(err)
+	}
+	return types
 }
@@ -0,0 +1,72 @@
+package ccretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+func Test_Retry_WithRetryableErrorTypes_ShouldMatchWrapperErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"transient error wrapping an error", ccerrors.NewTransientError("timeout", errors.New("dial tcp"), nil)},
+		{"wrapped transient error", errors.Wrap(ccerrors.NewTransientError("timeout", errors.New("dial tcp"), nil), "calling")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			retry := NewRetry(func() error { return tt.err }).
+				WithMaxAttempts(3).
+				WithRetryableErrorTypes(&ccerrors.TransientError{})
+
+			// Act
+			resp, err := retry.Run()
+
+			assert.Error(t, err)
+			assert.Equal(t, 3, resp.NumberOfAttempts())
+		})
+	}
+}
+
+func Test_Retry_WithNotRetryableErrorTypes_ShouldMatchWrappedErrors(t *testing.T) {
+	// Arrange
+	retry := NewRetry(func() error {
+		return errors.Wrap(ccerrors.NewTransientError("timeout", errors.New("dial tcp"), nil), "calling")
+	}).
+		WithMaxAttempts(3).
+		WithNotRetryableErrorTypes(&ccerrors.TransientError{})
+
+	// Act
+	resp, err := retry.Run()
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, resp.NumberOfAttempts())
+}
+
+func Test_Retry_ContextDone_ShouldStopWaiting(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	attemptErr := errors.New("unavailable")
+
+	retry := NewRetry(func() error { return attemptErr }).
+		WithContext(ctx).
+		WithMaxAttempts(3).
+		WithSleep(time.Second)
+
+	// Act
+	start := time.Now()
+	resp, err := retry.Run()
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, attemptErr)
+	assert.Equal(t, 1, resp.NumberOfAttempts())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
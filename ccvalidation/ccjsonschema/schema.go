@@ -0,0 +1,324 @@
+package ccjsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+const (
+	// Dialect is the JSON Schema dialect of the generated documents
+	Dialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// CustomRulesExtension lists the constraints that cannot be expressed in JSON Schema
+	CustomRulesExtension = "x-custom-rules"
+)
+
+// Schema is a JSON Schema object. It can be marshalled as JSON, or embedded in
+// the component schemas of an OpenAPI 3.1 document
+type Schema map[string]any
+
+// Generate returns the JSON Schema of T with the constraints of the validator.
+// Constraints that cannot be expressed, such as custom step funcs or the
+// conditional constraints of When steps and Switch branches, are listed in the
+// CustomRulesExtension of the root schema. A validator that does not
+// describe its constraints is reported as a single custom rule
+func Generate[T any](v ccvalidation.Validator[T]) Schema {
+	root := typeSchema(reflect.TypeFor[T](), map[reflect.Type]bool{})
+
+	var constraints []ccvalidation.Constraint
+	if describer, ok := v.(ccvalidation.Describer); ok {
+		constraints = describer.Describe()
+	} else {
+		constraints = []ccvalidation.Constraint{{
+			Code:   ccvalidation.CustomConstraintCode,
+			Params: map[string]any{"validator": fmt.Sprintf("%T", v)},
+		}}
+	}
+
+	var custom []ccvalidation.Constraint
+	for _, c := range constraints {
+		if !applyConstraint(root, c) {
+			custom = append(custom, c)
+		}
+	}
+	if len(custom) > 0 {
+		root[CustomRulesExtension] = custom
+	}
+
+	return root
+}
+
+// Document returns the Schema of Generate as a standalone JSON Schema document
+func Document[T any](v ccvalidation.Validator[T]) Schema {
+	schema := Generate(v)
+	schema["$schema"] = Dialect
+	schema["title"] = reflect.TypeFor[T]().Name()
+	return schema
+}
+
+// typeSchema returns the schema of the type, structs are described by the JSON
+// names of their exported fields
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeFor[time.Time]() {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": typeSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	default:
+		return Schema{}
+	}
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) Schema {
+	if visiting[t] {
+		return Schema{"type": "object"}
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	properties := Schema{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		properties[name] = typeSchema(field.Type, visiting)
+	}
+
+	return Schema{"type": "object", "properties": properties}
+}
+
+func jsonName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// applyConstraint sets the keywords of the constraint on the schema of its
+// path, returning false when the constraint cannot be expressed
+func applyConstraint(root Schema, c ccvalidation.Constraint) bool {
+	if c.Path == "" || c.Conditional {
+		return false
+	}
+
+	schema, parent, name := resolve(root, c.Path)
+	if schema == nil {
+		return false
+	}
+
+	switch c.Code {
+	case "required":
+		return markRequired(parent, name)
+	case "not_empty":
+		limited := setLimit(schema, "min", 1)
+		return markRequired(parent, name) && (limited || schema["type"] == "boolean")
+	case "min", "max", "len":
+		limit, ok := toFloat(c.Params[c.Code])
+		if !ok {
+			return false
+		}
+		if c.Code == "len" {
+			return setLimit(schema, "min", limit) && setLimit(schema, "max", limit)
+		}
+		return setLimit(schema, c.Code, limit)
+	case "length":
+		minimum, okMin := toFloat(c.Params["min"])
+		maximum, okMax := toFloat(c.Params["max"])
+		return okMin && okMax && setLimit(schema, "min", minimum) && setLimit(schema, "max", maximum)
+	case "between":
+		minimum, okMin := toFloat(c.Params["min"])
+		maximum, okMax := toFloat(c.Params["max"])
+		return okMin && okMax && isNumber(schema) &&
+			setLimit(schema, "min", minimum) && setLimit(schema, "max", maximum)
+	case "positive":
+		if !isNumber(schema) {
+			return false
+		}
+		schema["exclusiveMinimum"] = 0
+		return true
+	case "matches":
+		schema["pattern"] = c.Params["pattern"]
+		return true
+	case "email":
+		schema["format"] = "email"
+		return true
+	case "uuid":
+		schema["format"] = "uuid"
+		return true
+	case "url":
+		schema["format"] = "uri"
+		return true
+	case "oneof":
+		param, _ := c.Params["oneof"].(string)
+		schema["enum"] = enumValues(schema, strings.Fields(param))
+		return true
+	case "one_of":
+		schema["enum"] = toSlice(c.Params["options"])
+		return true
+	case "unique":
+		if schema["type"] != "array" {
+			return false
+		}
+		schema["uniqueItems"] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// resolve returns the schema at the path, and the object schema holding it
+// with its property name. Elements of collections have no parent
+func resolve(root Schema, path string) (schema Schema, parent Schema, name string) {
+	schema = root
+	for _, segment := range splitPath(path) {
+		if segment == ccvalidation.ElementPath {
+			schema, parent, name = elements(schema), nil, ""
+		} else {
+			schema, parent, name = property(schema, segment), schema, segment
+		}
+		if schema == nil {
+			return nil, nil, ""
+		}
+	}
+	return schema, parent, name
+}
+
+// splitPath splits a path as items[].sku into items, [] and sku
+func splitPath(path string) []string {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		name, _, _ := strings.Cut(part, ccvalidation.ElementPath)
+		if name != "" {
+			segments = append(segments, name)
+		}
+		for i := strings.Count(part, ccvalidation.ElementPath); i > 0; i-- {
+			segments = append(segments, ccvalidation.ElementPath)
+		}
+	}
+	return segments
+}
+
+func property(schema Schema, name string) Schema {
+	properties, _ := schema["properties"].(Schema)
+	prop, _ := properties[name].(Schema)
+	return prop
+}
+
+func elements(schema Schema) Schema {
+	if items, ok := schema["items"].(Schema); ok {
+		return items
+	}
+	values, _ := schema["additionalProperties"].(Schema)
+	return values
+}
+
+func markRequired(parent Schema, name string) bool {
+	if parent == nil {
+		return false
+	}
+
+	required, _ := parent["required"].([]string)
+	if !slices.Contains(required, name) {
+		parent["required"] = append(required, name)
+	}
+	return true
+}
+
+// setLimit sets the minimum or maximum keyword matching the schema type
+func setLimit(schema Schema, bound string, limit float64) bool {
+	var keywords map[string]string
+	switch schema["type"] {
+	case "string":
+		keywords = map[string]string{"min": "minLength", "max": "maxLength"}
+	case "array":
+		keywords = map[string]string{"min": "minItems", "max": "maxItems"}
+	case "object":
+		keywords = map[string]string{"min": "minProperties", "max": "maxProperties"}
+	case "integer", "number":
+		schema[map[string]string{"min": "minimum", "max": "maximum"}[bound]] = limit
+		return true
+	default:
+		return false
+	}
+
+	schema[keywords[bound]] = int(limit)
+	return true
+}
+
+func isNumber(schema Schema) bool {
+	return schema["type"] == "integer" || schema["type"] == "number"
+}
+
+// enumValues converts the tag options to the type of the schema
+func enumValues(schema Schema, options []string) []any {
+	values := make([]any, 0, len(options))
+	for _, option := range options {
+		if f, err := strconv.ParseFloat(option, 64); err == nil && isNumber(schema) {
+			values = append(values, f)
+			continue
+		}
+		values = append(values, option)
+	}
+	return values
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case nil:
+		return 0, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func toSlice(value any) []any {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return []any{value}
+	}
+
+	values := make([]any, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values
+}
@@ -0,0 +1,72 @@
+package ccjsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccvalidation"
+	"github.com/sts-solutions/base-code/ccvalidation/rules"
+)
+
+type testItem struct {
+	SKU      string `json:"sku" validate:"required,min=3"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type testOrder struct {
+	ID     string     `json:"id" validate:"required,len=8"`
+	Email  string     `json:"email" validate:"omitempty,email"`
+	Status string     `json:"status" validate:"oneof=open closed"`
+	Items  []testItem `json:"items" validate:"min=1"`
+	Tags   []string   `json:"tags"`
+}
+
+func Test_Generate_TagValidator_ShouldExpressConstraints(t *testing.T) {
+	// Act
+	schema := Generate(ccvalidation.FromTags[testOrder]())
+
+	// Assert
+	properties := schema["properties"].(Schema)
+	assert.Equal(t, []string{"id"}, schema["required"])
+	assert.Equal(t, Schema{"type": "string", "minLength": 8, "maxLength": 8}, properties["id"])
+	assert.Equal(t, Schema{"type": "string", "format": "email"}, properties["email"])
+	assert.Equal(t, []any{"open", "closed"}, properties["status"].(Schema)["enum"])
+
+	items := properties["items"].(Schema)
+	assert.Equal(t, 1, items["minItems"])
+	item := items["items"].(Schema)
+	assert.Equal(t, []string{"sku"}, item["required"])
+	assert.Equal(t, Schema{"type": "integer", "minimum": 1.0, "maximum": 10.0}, item["properties"].(Schema)["quantity"])
+	assert.NotContains(t, schema, CustomRulesExtension)
+}
+
+func Test_Generate_CustomSteps_ShouldBeListedAsCustomRules(t *testing.T) {
+	// Arrange
+	v := ccvalidation.New[testOrder]()
+	v.AddRules(
+		rules.Field("tags", func(o testOrder) []string { return o.Tags },
+			rules.Unique[string](), rules.Each(rules.Length[string](1, 5))),
+	)
+	v.AddStep(func(o testOrder) error { return nil })
+
+	// Act
+	schema := Document[testOrder](v)
+
+	// Assert
+	assert.Equal(t, Dialect, schema["$schema"])
+	assert.Equal(t, "testOrder", schema["title"])
+
+	tags := schema["properties"].(Schema)["tags"].(Schema)
+	assert.Equal(t, true, tags["uniqueItems"])
+	assert.Equal(t, Schema{"type": "string", "minLength": 1, "maxLength": 5}, tags["items"])
+
+	custom := schema[CustomRulesExtension].([]ccvalidation.Constraint)
+	require.Len(t, custom, 1)
+	assert.Equal(t, ccvalidation.CustomConstraintCode, custom[0].Code)
+	assert.Contains(t, custom[0].Params["step"], "Test_Generate_CustomSteps_ShouldBeListedAsCustomRules")
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+}
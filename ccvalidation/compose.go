@@ -0,0 +1,94 @@
+package ccvalidation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Field adds a step validating the field selected from the request with the
+// validator, prefixing the failure paths with the field path:
+//
+//	ccvalidation.Field(orderValidator, "address", func(o Order) Address { return o.Address }, addressValidator)
+func Field[T any, U any](v *validator[T], path string, selector func(T) U, vldtr Validator[U]) *validator[T] {
+	v.addStep(validationStep[T]{
+		action: func(ctx context.Context, req T) error {
+			return vldtr.ValidateCtx(ctx, selector(req)).WithPathPrefix(path)
+		},
+		describe: describeValidator(vldtr, path),
+	})
+	return v
+}
+
+// ForEach adds a step validating every element of the slice selected from the
+// request with the validator, prefixing the failure paths with the element
+// path, e.g. items[2]. When the outer or the element validator breaks on
+// failure, the elements after the first invalid one are not validated
+func ForEach[T any, U any](v *validator[T], path string, selector func(T) []U, vldtr Validator[U]) *validator[T] {
+	action := func(ctx context.Context, req T) error {
+		result := Result{}
+		for i, elem := range selector(req) {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			if addElementResult(&result, vldtr.ValidateCtx(ctx, elem).WithPathPrefix(elemPath)) &&
+				breaksOnElementFailure(v, vldtr) {
+				break
+			}
+		}
+		return result
+	}
+
+	v.addStep(validationStep[T]{
+		action:   action,
+		describe: describeValidator(vldtr, path+ElementPath),
+	})
+	return v
+}
+
+// ForMap adds a step validating every value of the map selected from the
+// request with the validator, prefixing the failure paths with the key path,
+// e.g. prices[EUR]. Keys are validated in the order of their string
+// representation. When the outer or the value validator breaks on failure,
+// the values after the first invalid one are not validated
+func ForMap[T any, K comparable, U any](v *validator[T], path string, selector func(T) map[K]U, vldtr Validator[U]) *validator[T] {
+	action := func(ctx context.Context, req T) error {
+		values := selector(req)
+
+		keys := make([]K, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+
+		result := Result{}
+		for _, k := range keys {
+			keyPath := fmt.Sprintf("%s[%v]", path, k)
+			if addElementResult(&result, vldtr.ValidateCtx(ctx, values[k]).WithPathPrefix(keyPath)) &&
+				breaksOnElementFailure(v, vldtr) {
+				break
+			}
+		}
+		return result
+	}
+
+	v.addStep(validationStep[T]{
+		action:   action,
+		describe: describeValidator(vldtr, path+ElementPath),
+	})
+	return v
+}
+
+// addElementResult adds the entries of an element result, returning true when it failed
+func addElementResult(result *Result, elemResult Result) bool {
+	result.Merge(elemResult)
+	return elemResult.IsFailure()
+}
+
+func breaksOnElementFailure[T any, U any](v *validator[T], vldtr Validator[U]) bool {
+	if v.breakOnFailure {
+		return true
+	}
+	inner, ok := vldtr.(*validator[U])
+	return ok && inner.breakOnFailure
+}
@@ -0,0 +1,51 @@
+package ccvalidation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testComposeOrder struct {
+	Address testTagsAddress
+	Items   []testTagsItem
+	Prices  map[string]testTagsItem
+}
+
+func Test_Field_ForEach_ForMap_ShouldPrefixPaths(t *testing.T) {
+	// Arrange
+	v := New[testComposeOrder]()
+	Field(v, "address", func(o testComposeOrder) testTagsAddress { return o.Address }, FromTags[testTagsAddress]())
+	ForEach(v, "items", func(o testComposeOrder) []testTagsItem { return o.Items }, FromTags[testTagsItem]())
+	ForMap(v, "prices", func(o testComposeOrder) map[string]testTagsItem { return o.Prices }, FromTags[testTagsItem]())
+
+	order := testComposeOrder{
+		Address: testTagsAddress{Country: "ES"},
+		Items:   []testTagsItem{{SKU: "SKU-1", Quantity: 1}, {SKU: "SKU-2"}, {SKU: "SKU-3"}},
+		Prices:  map[string]testTagsItem{"USD": {SKU: "SKU-1"}, "EUR": {SKU: "SKU-1", Quantity: 1}},
+	}
+
+	// Act
+	result := v.Validate(order)
+
+	assert.Equal(t, []string{
+		"address.street is required",
+		"items[1].quantity must be greater than or equal to 1",
+		"items[2].quantity must be greater than or equal to 1",
+		"prices[USD].quantity must be greater than or equal to 1",
+	}, result.GetErrorMessages())
+}
+
+func Test_ForEach_InnerBreakOnFailure_ShouldStopAtFirstInvalidElement(t *testing.T) {
+	// Arrange
+	v := New[testComposeOrder]()
+	ForEach(v, "items", func(o testComposeOrder) []testTagsItem { return o.Items }, FromTags[testTagsItem]().BreakOnFailure())
+
+	// Act
+	result := v.Validate(testComposeOrder{Items: []testTagsItem{{SKU: "1"}, {SKU: "2"}}})
+
+	assert.Equal(t, []string{
+		"items[0].sku must start with SKU-",
+		"items[0].quantity must be greater than or equal to 1",
+	}, result.GetErrorMessages())
+}
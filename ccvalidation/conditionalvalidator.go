@@ -1,6 +1,10 @@
 package ccvalidation
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sort"
+)
 
 type conditionalValidator[TCond any, TRequest any] struct {
 	breakOnFailure   bool
@@ -51,7 +55,23 @@ func (v *conditionalValidator[TCond, TRequest]) BreakOnFailure() *conditionalVal
 	return v
 }
 
+func (v *conditionalValidator[TCond, TRequest]) Validate(req TRequest) Result {
+	return v.ValidateCtx(context.Background(), req)
+}
+
+// ValidateCtx validates the request with the validator of its condition, or
+// with the default validator when there is none. Without a condition func
+// only the default validator can be used
 func (v *conditionalValidator[TCond, TRequest]) ValidateCtx(ctx context.Context, req TRequest) Result {
+	if v.condition == nil {
+		if v.defaultValidator != nil {
+			return v.defaultValidator.ValidateCtx(ctx, req)
+		}
+		result := Result{}
+		result.AddErrorMessage("no condition defined")
+		return result
+	}
+
 	condition := v.condition(req)
 	validator, ok := v.validators[condition]
 	if !ok {
@@ -66,3 +86,24 @@ func (v *conditionalValidator[TCond, TRequest]) ValidateCtx(ctx context.Context,
 	result := validator.ValidateCtx(ctx, req)
 	return result
 }
+
+// Describe returns the constraints of the validators, marked as conditional,
+// in the order of the string representation of their conditions
+func (v *conditionalValidator[TCond, TRequest]) Describe() []Constraint {
+	conditions := make([]any, 0, len(v.validators))
+	for condition := range v.validators {
+		conditions = append(conditions, condition)
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		return fmt.Sprint(conditions[i]) < fmt.Sprint(conditions[j])
+	})
+
+	validators := make([]Validator[TRequest], 0, len(v.validators)+1)
+	for _, condition := range conditions {
+		validators = append(validators, v.validators[condition])
+	}
+	if v.defaultValidator != nil {
+		validators = append(validators, v.defaultValidator)
+	}
+	return describeBranches(validators)
+}
@@ -0,0 +1,105 @@
+package ccvalidation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+const (
+	// ElementPath is the path segment of the elements of slices, arrays and
+	// maps in the Constraint paths, e.g. items[].sku
+	ElementPath = "[]"
+
+	// CustomConstraintCode is the code of the Constraints of steps that cannot
+	// be described, such as the AddStep funcs
+	CustomConstraintCode = "custom"
+)
+
+// Constraint describes a rule checked by a validator on the field at the path,
+// e.g. {Path: "name", Code: "length", Params: {"min": 3, "max": 50}}
+type Constraint struct {
+	Path   string         `json:"path"`
+	Code   string         `json:"code"`
+	Params map[string]any `json:"params,omitempty"`
+	// Conditional is true when the constraint is only checked for some
+	// requests, e.g. the steps of When or the branches of Switch
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+// Describer is implemented by validators that describe the constraints they check
+type Describer interface {
+	Describe() []Constraint
+}
+
+// RuleStep is a validation step describing the constraints it checks, see AddRules
+type RuleStep[T any] interface {
+	// Check validates the request, returning a Result or an error on failure
+	Check(ctx context.Context, req T) error
+	// Constraints returns the constraints checked by the step
+	Constraints() []Constraint
+}
+
+// Describe returns the constraints checked by the validator steps. The steps
+// that cannot be described are returned as CustomConstraintCode constraints,
+// with a "step" param naming the step func or a "validator" param naming the
+// type of the nested validator
+func (v validator[T]) Describe() []Constraint {
+	constraints := make([]Constraint, 0, len(v.steps))
+	for _, step := range v.steps {
+		if step.describe != nil {
+			constraints = append(constraints, step.describe()...)
+		}
+	}
+	return constraints
+}
+
+// PrefixConstraints returns a copy of the constraints with their paths prefixed
+func PrefixConstraints(prefix string, constraints []Constraint) []Constraint {
+	prefixed := make([]Constraint, 0, len(constraints))
+	for _, c := range constraints {
+		c.Path = JoinPath(prefix, c.Path)
+		prefixed = append(prefixed, c)
+	}
+	return prefixed
+}
+
+// conditionalConstraints returns a copy of the constraints marked as conditional
+func conditionalConstraints(constraints []Constraint) []Constraint {
+	conditional := make([]Constraint, 0, len(constraints))
+	for _, c := range constraints {
+		c.Conditional = true
+		conditional = append(conditional, c)
+	}
+	return conditional
+}
+
+// describeValidator describes a nested validator, validators that are not a
+// Describer are described as a custom constraint
+func describeValidator(vldtr any, prefix string) func() []Constraint {
+	return func() []Constraint {
+		if describer, ok := vldtr.(Describer); ok {
+			return PrefixConstraints(prefix, describer.Describe())
+		}
+		return []Constraint{{
+			Path:   prefix,
+			Code:   CustomConstraintCode,
+			Params: map[string]any{"validator": fmt.Sprintf("%T", vldtr)},
+		}}
+	}
+}
+
+func describeCustomStep(step any) func() []Constraint {
+	name := "unknown"
+	if fn := runtime.FuncForPC(reflect.ValueOf(step).Pointer()); fn != nil {
+		name = fn.Name()
+	}
+
+	return func() []Constraint {
+		return []Constraint{{
+			Code:   CustomConstraintCode,
+			Params: map[string]any{"step": name},
+		}}
+	}
+}
@@ -0,0 +1,123 @@
+package ccvalidation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+)
+
+// InvalidCode is the code of the FieldErrors added without a specific rule
+const InvalidCode = "invalid"
+
+// Severity is the severity of a validation entry. Only errors make the
+// validation fail, warnings and infos are reported alongside a success
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// FieldError is a validation failure of a field
+type FieldError struct {
+	// Path is the path of the field, e.g. items[2].price. Empty for failures of the whole value
+	Path string `json:"path"`
+	// Code identifies the failed rule, e.g. required or min
+	Code string `json:"code"`
+	// Params holds the rule parameters, e.g. {"min": "3"}
+	Params map[string]any `json:"params,omitempty"`
+	// Message describes the failure, relative to the field, e.g. "is required"
+	Message string `json:"message"`
+	// Severity of the entry, empty for errors
+	Severity Severity `json:"severity,omitempty"`
+	// ErrorCode is the numeric ccerrors.ErrorCode of the entry, 0 when it has none
+	ErrorCode int `json:"error_code,omitempty"`
+}
+
+// NewFieldError creates a FieldError
+func NewFieldError(path string, code string, message string, params map[string]any) FieldError {
+	return FieldError{
+		Path:    path,
+		Code:    code,
+		Params:  params,
+		Message: message,
+	}
+}
+
+// Error returns the path followed by the message, e.g. "items[2].price must be greater than 0"
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + " " + e.Message
+}
+
+// WithPathPrefix returns a copy of the FieldError with its path prefixed, e.g.
+// "price" prefixed with "items[2]" is "items[2].price"
+func (e FieldError) WithPathPrefix(prefix string) FieldError {
+	e.Path = JoinPath(prefix, e.Path)
+	return e
+}
+
+// WithSeverity returns a copy of the FieldError with the severity
+func (e FieldError) WithSeverity(severity Severity) FieldError {
+	if severity == SeverityError {
+		severity = ""
+	}
+	e.Severity = severity
+	return e
+}
+
+// GetSeverity returns the severity of the entry, SeverityError when not set
+func (e FieldError) GetSeverity() Severity {
+	if e.Severity == "" {
+		return SeverityError
+	}
+	return e.Severity
+}
+
+// WithErrorCode returns a copy of the FieldError with the ErrorCode, e.g. a
+// code created with ccerrors.NewValidationErrorCode
+func (e FieldError) WithErrorCode(code ccerrors.ErrorCode) FieldError {
+	e.ErrorCode = code.Code()
+	return e
+}
+
+// GetErrorCode returns the ErrorCode of the entry, with its name when it has
+// been registered in ccerrors
+func (e FieldError) GetErrorCode() (ccerrors.ErrorCode, bool) {
+	if e.ErrorCode == 0 {
+		return ccerrors.ErrorCode{}, false
+	}
+	return ccerrors.FromCode(e.ErrorCode), true
+}
+
+// JoinPath joins field paths: "items" and "[2]" are joined as "items[2]",
+// "items[2]" and "price" as "items[2].price"
+func JoinPath(prefix string, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
+
+// toFieldError returns the FieldError of the failure, plain errors get an
+// empty path and InvalidCode
+func toFieldError(failure error) FieldError {
+	var fieldErr FieldError
+	if errors.As(failure, &fieldErr) {
+		return fieldErr
+	}
+	return FieldError{
+		Code:    InvalidCode,
+		Message: failure.Error(),
+	}
+}
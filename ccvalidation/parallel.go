@@ -0,0 +1,110 @@
+package ccvalidation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// validateParallel runs the batches of consecutive independent steps
+// concurrently and the other steps alone. The failures are merged in the order
+// of the steps. A failure breaking the validation cancels the context of the
+// steps still in flight, and a cancelled or expired context stops the
+// validation before the next batch
+func (v validator[T]) validateParallel(ctx context.Context, src T) Result {
+	result := Result{}
+
+	stepsCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for start := 0; start < len(v.steps); {
+		end := start + 1
+		if v.steps[start].independent {
+			for end < len(v.steps) && v.steps[end].independent {
+				end++
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			result.AddFailure(abortedError(err))
+			return result
+		}
+
+		var broken atomic.Bool
+		errs, completed := v.runSteps(stepsCtx, v.steps[start:end], src, func() {
+			broken.Store(true)
+			cancel()
+		})
+
+		for i, err := range errs {
+			if broken.Load() && errors.Is(err, context.Canceled) && ctx.Err() == nil {
+				continue
+			}
+			if addStepError(&result, err) && v.breaksOn(v.steps[start+i]) {
+				return result
+			}
+		}
+
+		if err := ctx.Err(); err != nil && (!completed || end < len(v.steps)) {
+			result.AddFailure(abortedError(err))
+			return result
+		}
+
+		start = end
+	}
+
+	return result
+}
+
+// runSteps runs the steps concurrently, limited by the validator concurrency,
+// returning their errors in order and whether all of them were started before
+// the context was done. breakFn is called when a step fails and breaks the validation
+func (v validator[T]) runSteps(ctx context.Context, steps []validationStep[T], src T,
+	breakFn func()) ([]error, bool) {
+
+	errs := make([]error, len(steps))
+	if len(steps) == 1 {
+		errs[0] = steps[0].action(ctx, src)
+		return errs, true
+	}
+
+	limit := v.concurrency
+	if limit <= 0 || limit > len(steps) {
+		limit = len(steps)
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return errs, false
+		}
+
+		wg.Add(1)
+		go func(i int, step validationStep[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = step.action(ctx, src)
+			if v.breaksOn(step) && addStepError(&Result{}, errs[i]) {
+				breakFn()
+			}
+		}(i, step)
+	}
+	wg.Wait()
+
+	return errs, true
+}
+
+func (v validator[T]) breaksOn(step validationStep[T]) bool {
+	return step.breakOnFailure || v.breakOnFailure
+}
+
+func abortedError(err error) error {
+	return fmt.Errorf("validation aborted: %w", err)
+}
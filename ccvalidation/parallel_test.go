@@ -0,0 +1,74 @@
+package ccvalidation
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sleepingStep(d time.Duration, err error) func(ctx context.Context, req string) error {
+	return func(ctx context.Context, req string) error {
+		select {
+		case <-time.After(d):
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func Test_ValidateCtx_Parallel_ShouldRunIndependentStepsConcurrently(t *testing.T) {
+	// Arrange
+	v := New[string]().Parallel(3)
+	v.AddIndependentStepCtx(
+		sleepingStep(50*time.Millisecond, errors.New("customer does not exist")),
+		sleepingStep(50*time.Millisecond, nil),
+		sleepingStep(10*time.Millisecond, errors.New("product does not exist")),
+	)
+
+	// Act
+	start := time.Now()
+	result := v.ValidateCtx(context.Background(), "order")
+
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, []string{"customer does not exist", "product does not exist"}, result.GetErrorMessages())
+}
+
+func Test_ValidateCtx_ParallelBreakOnFailure_ShouldCancelStepsInFlight(t *testing.T) {
+	// Arrange
+	var cancelled atomic.Bool
+	v := New[string]().Parallel(0).BreakOnFailure()
+	v.AddIndependentStepCtx(
+		func(ctx context.Context, req string) error {
+			<-ctx.Done()
+			cancelled.Store(true)
+			return ctx.Err()
+		},
+		sleepingStep(10*time.Millisecond, errors.New("customer does not exist")),
+	)
+	v.AddStepCtx(sleepingStep(0, errors.New("should not run")))
+
+	// Act
+	result := v.ValidateCtx(context.Background(), "order")
+
+	assert.True(t, cancelled.Load())
+	assert.Equal(t, []string{"customer does not exist"}, result.GetErrorMessages())
+}
+
+func Test_ValidateCtx_ParallelDeadlineExceeded_ShouldAbort(t *testing.T) {
+	// Arrange
+	v := New[string]().Parallel(1)
+	v.AddIndependentStepCtx(sleepingStep(time.Second, nil), sleepingStep(time.Second, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	result := v.ValidateCtx(ctx, "order")
+
+	assert.True(t, result.IsFailure())
+	assert.ErrorIs(t, result.GetFailures()[len(result.GetFailures())-1], context.DeadlineExceeded)
+}
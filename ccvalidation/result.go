@@ -1,14 +1,21 @@
 package ccvalidation
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccredact"
 )
 
-// Result holds validation results and errors
+// Result holds validation results and errors. Warnings and infos are kept
+// apart from the errors and do not make the validation fail
 type Result struct {
 	failures []error
+	warnings []FieldError
+	infos    []FieldError
 }
 
 // Error implements the error interface for Result
@@ -45,11 +52,25 @@ func (r *Result) AddErrorMessage(msg string) {
 }
 
 // AddFailure adds a validation failure to the Result
-// If the failure is nil, nothing is added
+// If the failure is nil, nothing is added. FieldErrors with a warning or info
+// severity are added as warnings or infos
 func (r *Result) AddFailure(failure error) {
 	if failure == nil {
 		return
 	}
+
+	var fieldErr FieldError
+	if errors.As(failure, &fieldErr) {
+		switch fieldErr.GetSeverity() {
+		case SeverityWarning:
+			r.warnings = append(r.warnings, fieldErr)
+			return
+		case SeverityInfo:
+			r.infos = append(r.infos, fieldErr)
+			return
+		}
+	}
+
 	r.failures = append(r.failures, failure)
 }
 
@@ -59,25 +80,57 @@ func (r *Result) AddError(err error) {
 	r.AddFailure(err)
 }
 
-// AddParameterIsNotValidError adds a validation error indicating that a parameter is not valid
-// If the name is empty or whitespace, nothing is added
+// AddParameterIsNotValidError adds a FieldError indicating that a parameter is not valid
+// If the name is empty or whitespace, nothing is added. The value is rendered
+// as a string redacted using the ccredact Policy, in the message and in the
+// "value" param, as the FieldError is sent to the clients
 func (r *Result) AddParameterIsNotValidError(name string, value any) {
 	if strings.TrimSpace(name) == "" {
 		return
 	}
 
+	redacted := ccredact.Value(name, fmt.Sprintf("%v", value))
+
 	var valMsg string
-	if v, ok := value.(string); ok {
-		valMsg = fmt.Sprintf("'%s'", v)
+	if _, ok := value.(string); ok {
+		valMsg = fmt.Sprintf("'%v'", redacted)
 	} else {
-		valMsg = fmt.Sprintf("%v", value)
+		valMsg = fmt.Sprintf("%v", redacted)
 	}
 
-	err := fmt.Errorf("%s is not valid: %s", name, valMsg)
-	r.AddError(err)
+	r.AddFieldError(name, InvalidCode, fmt.Sprintf("is not valid: %s", valMsg), map[string]any{"value": redacted})
+}
+
+// AddFieldError adds a validation failure of the field at the path
+func (r *Result) AddFieldError(path string, code string, message string, params map[string]any) {
+	r.AddFailure(NewFieldError(path, code, message, params))
+}
+
+// AddCodedError adds a validation failure of the field at the path with the
+// ErrorCode, its name is used as the FieldError code
+func (r *Result) AddCodedError(path string, code ccerrors.ErrorCode, message string) {
+	r.AddFailure(NewFieldError(path, code.Name(), message, nil).WithErrorCode(code))
+}
+
+// AddWarning adds a warning of the field at the path, it does not make the validation fail
+func (r *Result) AddWarning(path string, code string, message string, params map[string]any) {
+	r.AddFailure(NewFieldError(path, code, message, params).WithSeverity(SeverityWarning))
+}
+
+// AddInfo adds an informative entry of the field at the path
+func (r *Result) AddInfo(path string, code string, message string, params map[string]any) {
+	r.AddFailure(NewFieldError(path, code, message, params).WithSeverity(SeverityInfo))
+}
+
+// Merge adds the failures, warnings and infos of the other result
+func (r *Result) Merge(other Result) {
+	r.failures = append(r.failures, other.failures...)
+	r.warnings = append(r.warnings, other.warnings...)
+	r.infos = append(r.infos, other.infos...)
 }
 
-// IsSuccess returns true when no error has been added to the result
+// IsSuccess returns true when no error has been added to the result, warnings
+// and infos are ignored
 func (r Result) IsSuccess() bool {
 	return len(r.failures) == 0
 }
@@ -87,6 +140,35 @@ func (r Result) IsFailure() bool {
 	return !r.IsSuccess()
 }
 
+// IsEmpty returns true when no error, warning or info has been added to the result
+func (r Result) IsEmpty() bool {
+	return len(r.failures) == 0 && len(r.warnings) == 0 && len(r.infos) == 0
+}
+
+// HasWarnings returns true when any warning has been added to the result
+func (r Result) HasWarnings() bool {
+	return len(r.warnings) > 0
+}
+
+// GetWarnings returns the warnings of the result
+func (r Result) GetWarnings() []FieldError {
+	return r.warnings
+}
+
+// GetWarningMessages returns the messages of the warnings of the result
+func (r Result) GetWarningMessages() []string {
+	s := make([]string, 0, len(r.warnings))
+	for _, warning := range r.warnings {
+		s = append(s, warning.Error())
+	}
+	return s
+}
+
+// GetInfos returns the infos of the result
+func (r Result) GetInfos() []FieldError {
+	return r.infos
+}
+
 // GetFailures returns a list of all failures in the result
 // If no failures are found, returns an empty slice
 func (r Result) GetFailures() []error {
@@ -109,6 +191,99 @@ func (r Result) GetFailureMessages() []string {
 	return s
 }
 
+// GetFieldErrors returns all failures as FieldErrors, failures added as
+// plain errors have an empty path and InvalidCode
+func (r Result) GetFieldErrors() []FieldError {
+	fieldErrs := make([]FieldError, 0, len(r.failures))
+	for _, failure := range r.failures {
+		fieldErrs = append(fieldErrs, toFieldError(failure))
+	}
+	return fieldErrs
+}
+
+// GetFieldErrorsByPath returns the FieldErrors of the field at the path
+func (r Result) GetFieldErrorsByPath(path string) []FieldError {
+	fieldErrs := make([]FieldError, 0)
+	for _, fieldErr := range r.GetFieldErrors() {
+		if fieldErr.Path == path {
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+	return fieldErrs
+}
+
+// HasFieldError returns true when the field at the path has failures
+func (r Result) HasFieldError(path string) bool {
+	return len(r.GetFieldErrorsByPath(path)) > 0
+}
+
+// WithPathPrefix returns a copy of the result with the paths of its FieldErrors
+// prefixed, e.g. to nest the result of an item validator under "items[2]".
+// Failures added as plain errors are kept as they are
+func (r Result) WithPathPrefix(prefix string) Result {
+	if prefix == "" || r.IsEmpty() {
+		return r
+	}
+
+	prefixed := Result{
+		failures: make([]error, 0, len(r.failures)),
+		warnings: prefixFieldErrors(prefix, r.warnings),
+		infos:    prefixFieldErrors(prefix, r.infos),
+	}
+	for _, failure := range r.failures {
+		var fieldErr FieldError
+		if errors.As(failure, &fieldErr) {
+			failure = fieldErr.WithPathPrefix(prefix)
+		}
+		prefixed.failures = append(prefixed.failures, failure)
+	}
+	return prefixed
+}
+
+func prefixFieldErrors(prefix string, fieldErrs []FieldError) []FieldError {
+	if fieldErrs == nil {
+		return nil
+	}
+
+	prefixed := make([]FieldError, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		prefixed = append(prefixed, fieldErr.WithPathPrefix(prefix))
+	}
+	return prefixed
+}
+
+type resultJSON struct {
+	Errors   []FieldError `json:"errors"`
+	Warnings []FieldError `json:"warnings,omitempty"`
+	Infos    []FieldError `json:"infos,omitempty"`
+}
+
+// MarshalJSON renders the result as {"errors": [FieldError, ...]}, with the
+// "warnings" and "infos" members when there are any
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resultJSON{
+		Errors:   r.GetFieldErrors(),
+		Warnings: r.warnings,
+		Infos:    r.infos,
+	})
+}
+
+// UnmarshalJSON decodes a result rendered by MarshalJSON
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var rj resultJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+
+	*r = Result{}
+	for _, entries := range [][]FieldError{rj.Errors, rj.Warnings, rj.Infos} {
+		for _, fieldErr := range entries {
+			r.AddFailure(fieldErr)
+		}
+	}
+	return nil
+}
+
 // GetErrorMessages returns a list of all error messages in the result
 // If no errors are found, returns an empty slice
 func (r Result) GetErrorMessages() []string {
@@ -0,0 +1,128 @@
+package ccvalidation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sts-solutions/base-code/ccerrors"
+	"github.com/sts-solutions/base-code/ccredact"
+)
+
+func Test_Result_AddFieldError_ShouldBeQueryableByPath(t *testing.T) {
+	// Arrange
+	result := Result{}
+	result.AddFieldError("items[2].price", "min", "must be greater than or equal to 1", map[string]any{"min": "1"})
+	result.AddParameterIsNotValidError("name", "")
+	result.AddErrorMessage("request is empty")
+
+	// Act
+	priceErrs := result.GetFieldErrorsByPath("items[2].price")
+
+	require.Len(t, priceErrs, 1)
+	assert.Equal(t, "min", priceErrs[0].Code)
+	assert.True(t, result.HasFieldError("name"))
+	assert.False(t, result.HasFieldError("email"))
+	assert.Equal(t, []string{
+		"items[2].price must be greater than or equal to 1",
+		"name is not valid: ''",
+		"request is empty",
+	}, result.GetErrorMessages())
+}
+
+func Test_Validator_AddValidatorAt_ShouldPrefixPaths(t *testing.T) {
+	// Arrange
+	address := FromTags[testTagsAddress]()
+	v := New[testTagsAddress]()
+	v.AddValidatorAt("shipping.address", address)
+
+	// Act
+	result := v.Validate(testTagsAddress{Country: "ES"})
+
+	assert.Equal(t, []FieldError{
+		{Path: "shipping.address.street", Code: "required", Message: "is required"},
+	}, result.GetFieldErrors())
+}
+
+func Test_Result_JSON_ShouldRoundTrip(t *testing.T) {
+	// Arrange
+	result := Result{}
+	result.AddFieldError("id", "len", "must have exactly 36 characters", map[string]any{"len": "36"})
+	result.AddFailure(errors.New("request is empty"))
+
+	// Act
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.JSONEq(t, `{"errors":[
+		{"path":"id","code":"len","params":{"len":"36"},"message":"must have exactly 36 characters"},
+		{"path":"","code":"invalid","message":"request is empty"}
+	]}`, string(data))
+	assert.Equal(t, result.GetFieldErrors(), decoded.GetFieldErrors())
+}
+
+func Test_Result_Warnings_ShouldNotFailValidation(t *testing.T) {
+	// Arrange
+	v := New[testTagsAddress]()
+	v.AddStep(func(a testTagsAddress) error {
+		result := Result{}
+		result.AddWarning("delivery_date", "holiday", "is a public holiday", nil)
+		result.AddInfo("country", "eu", "is in the EU", nil)
+		return result
+	})
+	v.AddValidatorAt("address", FromTags[testTagsAddress]())
+
+	// Act
+	result := v.Validate(testTagsAddress{Street: "Main St", Country: "ES"})
+
+	assert.True(t, result.IsSuccess())
+	assert.True(t, result.HasWarnings())
+	assert.Equal(t, []string{"delivery_date is a public holiday"}, result.GetWarningMessages())
+	assert.Equal(t, SeverityInfo, result.GetInfos()[0].GetSeverity())
+	assert.Empty(t, result.Error())
+}
+
+func Test_Result_CodedErrorAndWarning_ShouldRoundTripJSON(t *testing.T) {
+	// Arrange
+	code := ccerrors.NewValidationErrorCode(301, "order_closed")
+	result := Result{}
+	result.AddCodedError("status", code, "is closed")
+	result.AddFailure(NewFieldError("total", "max", "is too high", nil).WithSeverity(SeverityWarning))
+
+	// Act
+	data, err := json.Marshal(result.WithPathPrefix("order"))
+	require.NoError(t, err)
+
+	var decoded Result
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.JSONEq(t, `{
+		"errors":[{"path":"order.status","code":"order_closed","message":"is closed","error_code":91301}],
+		"warnings":[{"path":"order.total","code":"max","message":"is too high","severity":"warning"}]
+	}`, string(data))
+	errCode, ok := decoded.GetFieldErrors()[0].GetErrorCode()
+	assert.True(t, ok)
+	assert.Equal(t, code.Code(), errCode.Code())
+	assert.Equal(t, result.WithPathPrefix("order").GetWarnings(), decoded.GetWarnings())
+}
+
+func Test_Result_AddParameterIsNotValidError_ShouldRedactAndStringifyTheValue(t *testing.T) {
+	// Arrange
+	result := Result{}
+
+	// Act
+	result.AddParameterIsNotValidError("password", "s3cr3t")
+	result.AddParameterIsNotValidError("callback", make(chan int))
+	body, err := json.Marshal(result.GetFieldErrors())
+
+	require.NoError(t, err)
+	fieldErrs := result.GetFieldErrors()
+	assert.Equal(t, ccredact.Redacted, fieldErrs[0].Params["value"])
+	assert.IsType(t, "", fieldErrs[1].Params["value"])
+	assert.NotContains(t, string(body), "s3cr3t")
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+// Rule is a typed, reusable check of a value, identified by a code and its
+// parameters so failures can be reported as ccvalidation.FieldErrors
+type Rule[V any] struct {
+	code   string
+	params map[string]any
+	check  func(value V) error
+	// elements are the constraints checked on the elements by Each
+	elements []ccvalidation.Constraint
+}
+
+// New creates a Rule. The check returns an error whose message is relative to
+// the field, e.g. "must be positive", or a ccvalidation.Result of FieldErrors
+// whose paths are relative to the field
+func New[V any](code string, params map[string]any, check func(value V) error) Rule[V] {
+	return Rule[V]{
+		code:   code,
+		params: params,
+		check:  check,
+	}
+}
+
+// Code returns the code of the rule, e.g. "length"
+func (r Rule[V]) Code() string {
+	return r.code
+}
+
+// Params returns the parameters of the rule, e.g. {"min": 3, "max": 50}
+func (r Rule[V]) Params() map[string]any {
+	return r.params
+}
+
+// Validate checks the value of the field, returning a ccvalidation.Result with
+// the FieldErrors of the field, or nil when the value is valid
+func (r Rule[V]) Validate(field string, value V) error {
+	result := ccvalidation.Result{}
+	r.addFailures(&result, field, value)
+	if !result.IsEmpty() {
+		return result
+	}
+	return nil
+}
+
+func (r Rule[V]) addFailures(result *ccvalidation.Result, field string, value V) {
+	err := r.check(value)
+	if err == nil {
+		return
+	}
+
+	var nested ccvalidation.Result
+	if errors.As(err, &nested) {
+		result.Merge(nested.WithPathPrefix(field))
+		return
+	}
+
+	var fieldErr ccvalidation.FieldError
+	if errors.As(err, &fieldErr) {
+		result.AddFailure(fieldErr.WithPathPrefix(field))
+		return
+	}
+
+	result.AddFieldError(field, r.code, err.Error(), r.params)
+}
+
+// constraints describes the rule checked on the field
+func (r Rule[V]) constraints(field string) []ccvalidation.Constraint {
+	if r.elements != nil {
+		return ccvalidation.PrefixConstraints(field+ccvalidation.ElementPath, r.elements)
+	}
+	return []ccvalidation.Constraint{{Path: field, Code: r.code, Params: r.params}}
+}
+
+// FieldRules is a validation step applying rules to a field of the request
+type FieldRules[T any, V any] struct {
+	field    string
+	selector func(T) V
+	rules    []Rule[V]
+}
+
+// For returns a validation step, to be added with AddStep, applying the rules
+// to the field value selected from the request. All the rules are checked:
+//
+//	v.AddStep(rules.For("name", func(o Order) string { return o.Name },
+//		rules.NotEmpty[string](), rules.Length[string](3, 50)))
+//
+// Use Field to add the rules with AddRules so they can be described
+func For[T any, V any](field string, selector func(T) V, rules ...Rule[V]) func(T) error {
+	return Field(field, selector, rules...).Validate
+}
+
+// Field returns a validation step, to be added with AddRules, applying the
+// rules to the field value selected from the request and describing them,
+// e.g. for ccjsonschema:
+//
+//	v.AddRules(rules.Field("name", func(o Order) string { return o.Name },
+//		rules.NotEmpty[string](), rules.Length[string](3, 50)))
+func Field[T any, V any](field string, selector func(T) V, rules ...Rule[V]) FieldRules[T, V] {
+	return FieldRules[T, V]{
+		field:    field,
+		selector: selector,
+		rules:    rules,
+	}
+}
+
+// Validate applies the rules to the field of the request, returning a
+// ccvalidation.Result with the FieldErrors, or nil when the field is valid
+func (f FieldRules[T, V]) Validate(req T) error {
+	value := f.selector(req)
+
+	result := ccvalidation.Result{}
+	for _, rule := range f.rules {
+		rule.addFailures(&result, f.field, value)
+	}
+	if !result.IsEmpty() {
+		return result
+	}
+	return nil
+}
+
+// Check implements ccvalidation.RuleStep
+func (f FieldRules[T, V]) Check(_ context.Context, req T) error {
+	return f.Validate(req)
+}
+
+// Constraints implements ccvalidation.RuleStep
+func (f FieldRules[T, V]) Constraints() []ccvalidation.Constraint {
+	constraints := make([]ccvalidation.Constraint, 0, len(f.rules))
+	for _, rule := range f.rules {
+		constraints = append(constraints, rule.constraints(f.field)...)
+	}
+	return constraints
+}
+
+// Each applies the rules to every element of a slice, the failures are
+// reported at the element path, e.g. tags[2]
+func Each[V any](rules ...Rule[V]) Rule[[]V] {
+	elements := make([]ccvalidation.Constraint, 0, len(rules))
+	for _, rule := range rules {
+		elements = append(elements, rule.constraints("")...)
+	}
+
+	each := New("each", nil, func(values []V) error {
+		result := ccvalidation.Result{}
+		for i, value := range values {
+			for _, rule := range rules {
+				rule.addFailures(&result, elementPath(i), value)
+			}
+		}
+		if result.IsFailure() {
+			return result
+		}
+		return nil
+	})
+	each.elements = elements
+
+	return each
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Number is the constraint of the numeric rules
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// now returns the current time used by the time rules
+	now = time.Now
+)
+
+// NotEmpty checks the value is not the zero value, nor an empty slice or map
+func NotEmpty[V any]() Rule[V] {
+	return New("not_empty", nil, func(value V) error {
+		rv := reflect.ValueOf(value)
+		empty := !rv.IsValid() || rv.IsZero()
+		if !empty && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) {
+			empty = rv.Len() == 0
+		}
+
+		if empty {
+			return errors.New("must not be empty")
+		}
+		return nil
+	})
+}
+
+// Length checks the number of characters of the string is between min and max, both included
+func Length[V ~string](min int, max int) Rule[V] {
+	return New("length", map[string]any{"min": min, "max": max}, func(value V) error {
+		if n := utf8.RuneCountInString(string(value)); n < min || n > max {
+			return fmt.Errorf("must have between %d and %d characters", min, max)
+		}
+		return nil
+	})
+}
+
+// Matches checks the string matches the regular expression
+func Matches[V ~string](re *regexp.Regexp) Rule[V] {
+	return New("matches", map[string]any{"pattern": re.String()}, func(value V) error {
+		if !re.MatchString(string(value)) {
+			return fmt.Errorf("must match the pattern %s", re.String())
+		}
+		return nil
+	})
+}
+
+// Email checks the string is an email address, without display name
+func Email[V ~string]() Rule[V] {
+	return New("email", nil, func(value V) error {
+		addr, err := mail.ParseAddress(string(value))
+		if err != nil || addr.Address != string(value) {
+			return errors.New("must be a valid email address")
+		}
+		return nil
+	})
+}
+
+// UUID checks the string is a UUID in its canonical form
+func UUID[V ~string]() Rule[V] {
+	return New("uuid", map[string]any{"pattern": uuidPattern.String()}, func(value V) error {
+		if !uuidPattern.MatchString(string(value)) {
+			return errors.New("must be a valid UUID")
+		}
+		return nil
+	})
+}
+
+// URL checks the string is an absolute URL
+func URL[V ~string]() Rule[V] {
+	return New("url", nil, func(value V) error {
+		u, err := url.ParseRequestURI(string(value))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("must be a valid URL")
+		}
+		return nil
+	})
+}
+
+// Between checks the value is between min and max, both included
+func Between[V cmp.Ordered](min V, max V) Rule[V] {
+	return New("between", map[string]any{"min": min, "max": max}, func(value V) error {
+		if value < min || value > max {
+			return fmt.Errorf("must be between %v and %v", min, max)
+		}
+		return nil
+	})
+}
+
+// OneOf checks the value is one of the options
+func OneOf[V comparable](options ...V) Rule[V] {
+	return New("one_of", map[string]any{"options": options}, func(value V) error {
+		if !slices.Contains(options, value) {
+			return fmt.Errorf("must be one of %v", options)
+		}
+		return nil
+	})
+}
+
+// Positive checks the number is greater than zero
+func Positive[V Number]() Rule[V] {
+	return New("positive", nil, func(value V) error {
+		if value <= 0 {
+			return errors.New("must be positive")
+		}
+		return nil
+	})
+}
+
+// NotInFuture checks the time is not after the current time
+func NotInFuture() Rule[time.Time] {
+	return New("not_in_future", nil, func(value time.Time) error {
+		if value.After(now()) {
+			return errors.New("must not be in the future")
+		}
+		return nil
+	})
+}
+
+// NotInPast checks the time is not before the current time
+func NotInPast() Rule[time.Time] {
+	return New("not_in_past", nil, func(value time.Time) error {
+		if value.Before(now()) {
+			return errors.New("must not be in the past")
+		}
+		return nil
+	})
+}
+
+// Unique checks the slice has no duplicated elements
+func Unique[V comparable]() Rule[[]V] {
+	return New("unique", nil, func(values []V) error {
+		seen := make(map[V]struct{}, len(values))
+		for _, value := range values {
+			if _, ok := seen[value]; ok {
+				return fmt.Errorf("must not contain duplicates, %v is repeated", value)
+			}
+			seen[value] = struct{}{}
+		}
+		return nil
+	})
+}
+
+func elementPath(index int) string {
+	return "[" + strconv.Itoa(index) + "]"
+}
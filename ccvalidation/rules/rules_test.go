@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sts-solutions/base-code/ccvalidation"
+)
+
+type testOrder struct {
+	ID        string
+	Reference string
+	Email     string
+	Callback  string
+	Status    string
+	Quantity  int
+	Discount  float64
+	CreatedAt time.Time
+	Tags      []string
+}
+
+func newTestOrderValidator() ccvalidation.Validator[testOrder] {
+	v := ccvalidation.New[testOrder]()
+	v.AddStep(
+		For("id", func(o testOrder) string { return o.ID }, NotEmpty[string](), UUID[string]()),
+		For("reference", func(o testOrder) string { return o.Reference }, Length[string](3, 10), Matches[string](regexp.MustCompile(`^REF-`))),
+		For("email", func(o testOrder) string { return o.Email }, Email[string]()),
+		For("callback", func(o testOrder) string { return o.Callback }, URL[string]()),
+		For("status", func(o testOrder) string { return o.Status }, OneOf("open", "closed")),
+		For("quantity", func(o testOrder) int { return o.Quantity }, Positive[int]()),
+		For("discount", func(o testOrder) float64 { return o.Discount }, Between(0.0, 0.5)),
+		For("created_at", func(o testOrder) time.Time { return o.CreatedAt }, NotInFuture()),
+		For("tags", func(o testOrder) []string { return o.Tags }, Unique[string](), Each(Length[string](1, 5))),
+	)
+	return v
+}
+
+func Test_Rules_ValidOrder_ShouldSucceed(t *testing.T) {
+	// Arrange
+	order := testOrder{
+		ID:        "6f1c2a9e-3b7d-4c1e-9a2f-0d8e5b4c3a21",
+		Reference: "REF-1",
+		Email:     "buyer@example.com",
+		Callback:  "https://example.com/hooks",
+		Status:    "open",
+		Quantity:  2,
+		Discount:  0.1,
+		CreatedAt: time.Now().Add(-time.Hour),
+		Tags:      []string{"a", "b"},
+	}
+
+	// Act
+	result := newTestOrderValidator().Validate(order)
+
+	assert.True(t, result.IsSuccess(), result.Error())
+}
+
+func Test_Rules_InvalidOrder_ShouldReturnFieldErrors(t *testing.T) {
+	// Arrange
+	order := testOrder{
+		Reference: "X",
+		Email:     "buyer",
+		Callback:  "/hooks",
+		Status:    "pending",
+		Discount:  0.8,
+		CreatedAt: time.Now().Add(time.Hour),
+		Tags:      []string{"a", "toolong", "a"},
+	}
+
+	// Act
+	result := newTestOrderValidator().Validate(order)
+
+	assert.Equal(t, []string{
+		"id must not be empty",
+		"id must be a valid UUID",
+		"reference must have between 3 and 10 characters",
+		"reference must match the pattern ^REF-",
+		"email must be a valid email address",
+		"callback must be a valid URL",
+		"status must be one of [open closed]",
+		"quantity must be positive",
+		"discount must be between 0 and 0.5",
+		"created_at must not be in the future",
+		"tags must not contain duplicates, a is repeated",
+		"tags[1] must have between 1 and 5 characters",
+	}, result.GetErrorMessages())
+
+	lengthErrs := result.GetFieldErrorsByPath("reference")
+	assert.Equal(t, "length", lengthErrs[0].Code)
+	assert.Equal(t, map[string]any{"min": 3, "max": 10}, lengthErrs[0].Params)
+}
@@ -0,0 +1,92 @@
+package ccvalidation
+
+import "context"
+
+type switchCase[T any] struct {
+	predicate func(T) bool
+	validator Validator[T]
+}
+
+type switchValidator[T any] struct {
+	breakOnFailure   bool
+	cases            []switchCase[T]
+	defaultValidator Validator[T]
+}
+
+// Switch creates a validator of ordered branches: the request is validated by
+// the validator of the first case whose predicate is true, or by the default
+// validator. Without a matching case nor a default validator it succeeds:
+//
+//	ccvalidation.Switch[Customer]().
+//		WithCase(func(c Customer) bool { return c.IsEU() && c.Type == Business }, euBusinessValidator).
+//		WithCase(func(c Customer) bool { return c.IsEU() }, euValidator).
+//		WithDefaultValidator(defaultValidator)
+func Switch[T any]() *switchValidator[T] {
+	return &switchValidator[T]{}
+}
+
+func (v *switchValidator[T]) WithCase(predicate func(T) bool, validator Validator[T]) *switchValidator[T] {
+	v.cases = append(v.cases, switchCase[T]{
+		predicate: predicate,
+		validator: v.wrap(validator),
+	})
+	return v
+}
+
+func (v *switchValidator[T]) WithDefaultValidator(validator Validator[T]) *switchValidator[T] {
+	v.defaultValidator = v.wrap(validator)
+	return v
+}
+
+func (v *switchValidator[T]) BreakOnFailure() *switchValidator[T] {
+	v.breakOnFailure = true
+	return v
+}
+
+func (v *switchValidator[T]) Validate(req T) Result {
+	return v.ValidateCtx(context.Background(), req)
+}
+
+func (v *switchValidator[T]) ValidateCtx(ctx context.Context, req T) Result {
+	for _, c := range v.cases {
+		if c.predicate(req) {
+			return c.validator.ValidateCtx(ctx, req)
+		}
+	}
+
+	if v.defaultValidator != nil {
+		return v.defaultValidator.ValidateCtx(ctx, req)
+	}
+	return Result{}
+}
+
+// Describe returns the constraints of the branch validators, marked as conditional
+func (v *switchValidator[T]) Describe() []Constraint {
+	validators := make([]Validator[T], 0, len(v.cases)+1)
+	for _, c := range v.cases {
+		validators = append(validators, c.validator)
+	}
+	if v.defaultValidator != nil {
+		validators = append(validators, v.defaultValidator)
+	}
+	return describeBranches(validators)
+}
+
+func (v *switchValidator[T]) wrap(validator Validator[T]) Validator[T] {
+	if !v.breakOnFailure {
+		return validator
+	}
+	val := NewValidator[T]().BreakOnFailure()
+	val.AddValidator(validator)
+	return val
+}
+
+// describeBranches describes the validators of the branches of a conditional
+// validation, their constraints are marked as conditional
+func describeBranches[T any](validators []Validator[T]) []Constraint {
+	var constraints []Constraint
+	for _, validator := range validators {
+		constraints = append(constraints, conditionalConstraints(describeValidator(validator, "")())...)
+	}
+	return constraints
+}
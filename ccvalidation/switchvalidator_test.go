@@ -0,0 +1,88 @@
+package ccvalidation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSwitchCustomer struct {
+	Country   string
+	Business  bool
+	VATNumber string
+}
+
+func testIsEU(c testSwitchCustomer) bool {
+	return c.Country == "ES" || c.Country == "FR"
+}
+
+func testVATRequired(c testSwitchCustomer) error {
+	if c.VATNumber == "" {
+		return NewFieldError("vat_number", "required", "is required", nil)
+	}
+	return nil
+}
+
+func Test_Validator_WhenUnless_ShouldRunStepsOnlyWhenPredicateHolds(t *testing.T) {
+	// Arrange
+	v := New[testSwitchCustomer]()
+	v.AddStep(testVATRequired)
+	v.When(func(c testSwitchCustomer) bool { return testIsEU(c) && c.Business })
+	v.AddStep(func(c testSwitchCustomer) error { return errors.New("country is not supported") })
+	v.Unless(testIsEU)
+
+	// Act
+	euBusiness := v.Validate(testSwitchCustomer{Country: "ES", Business: true})
+	euConsumer := v.Validate(testSwitchCustomer{Country: "ES"})
+	other := v.Validate(testSwitchCustomer{Country: "US", Business: true})
+
+	assert.Equal(t, []string{"vat_number is required"}, euBusiness.GetErrorMessages())
+	assert.True(t, euConsumer.IsSuccess())
+	assert.Equal(t, []string{"country is not supported"}, other.GetErrorMessages())
+	for _, c := range v.Describe() {
+		assert.True(t, c.Conditional)
+	}
+}
+
+func Test_Switch_ShouldUseFirstMatchingCaseOrDefault(t *testing.T) {
+	// Arrange
+	vat := New[testSwitchCustomer]()
+	vat.AddStep(testVATRequired)
+	unsupported := New[testSwitchCustomer]()
+	unsupported.AddStep(func(c testSwitchCustomer) error { return errors.New("country is not supported") })
+	consumer := New[testSwitchCustomer]()
+	consumer.AddStep(func(c testSwitchCustomer) error { return nil })
+
+	v := New[testSwitchCustomer]()
+	v.AddValidator(Switch[testSwitchCustomer]().
+		WithCase(func(c testSwitchCustomer) bool { return testIsEU(c) && c.Business }, vat).
+		WithCase(testIsEU, consumer).
+		WithDefaultValidator(unsupported))
+
+	// Act
+	euBusiness := v.Validate(testSwitchCustomer{Country: "FR", Business: true})
+	euConsumer := v.Validate(testSwitchCustomer{Country: "FR"})
+	other := v.Validate(testSwitchCustomer{Country: "US"})
+
+	assert.Equal(t, []string{"vat_number is required"}, euBusiness.GetErrorMessages())
+	assert.True(t, euConsumer.IsSuccess())
+	assert.Equal(t, []string{"country is not supported"}, other.GetErrorMessages())
+}
+
+func Test_ConditionalValidator_WithoutCondition_ShouldNotPanic(t *testing.T) {
+	// Arrange
+	vat := New[testSwitchCustomer]()
+	vat.AddStep(testVATRequired)
+	withDefault := NewConditionalValidator[string, testSwitchCustomer]().WithDefaultValidator(vat)
+	withoutDefault := NewConditionalValidator[string, testSwitchCustomer]()
+
+	v := New[testSwitchCustomer]()
+	v.AddValidator(withDefault)
+
+	// Act
+	result := v.Validate(testSwitchCustomer{})
+
+	assert.Equal(t, []string{"vat_number is required"}, result.GetErrorMessages())
+	assert.Equal(t, []string{"no condition defined"}, withoutDefault.Validate(testSwitchCustomer{}).GetErrorMessages())
+}
@@ -0,0 +1,108 @@
+package ccvalidation
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+func requiredRule(string) (TagCheck, error) {
+	return func(value reflect.Value) error {
+		switch value.Kind() {
+		case reflect.Slice, reflect.Map:
+			if value.Len() == 0 {
+				return errors.New("is required")
+			}
+		default:
+			if value.IsZero() {
+				return errors.New("is required")
+			}
+		}
+		return nil
+	}, nil
+}
+
+func minRule(param string) (TagCheck, error) {
+	return sizeRule(param, func(size float64, limit float64) bool { return size >= limit },
+		"must have at least %s %s", "must be greater than or equal to %s")
+}
+
+func maxRule(param string) (TagCheck, error) {
+	return sizeRule(param, func(size float64, limit float64) bool { return size <= limit },
+		"must have at most %s %s", "must be less than or equal to %s")
+}
+
+func lenRule(param string) (TagCheck, error) {
+	return sizeRule(param, func(size float64, limit float64) bool { return size == limit },
+		"must have exactly %s %s", "must be equal to %s")
+}
+
+// sizeRule compares the length of strings and collections, or the value of
+// numbers, with the limit of the parameter
+func sizeRule(param string, valid func(size float64, limit float64) bool,
+	lengthMsg string, numberMsg string) (TagCheck, error) {
+
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q is not a number", param)
+	}
+
+	return func(value reflect.Value) error {
+		switch value.Kind() {
+		case reflect.String:
+			if !valid(float64(utf8.RuneCountInString(value.String())), limit) {
+				return fmt.Errorf(lengthMsg, param, "characters")
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if !valid(float64(value.Len()), limit) {
+				return fmt.Errorf(lengthMsg, param, "items")
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !valid(float64(value.Int()), limit) {
+				return fmt.Errorf(numberMsg, param)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !valid(float64(value.Uint()), limit) {
+				return fmt.Errorf(numberMsg, param)
+			}
+		case reflect.Float32, reflect.Float64:
+			if !valid(value.Float(), limit) {
+				return fmt.Errorf(numberMsg, param)
+			}
+		}
+		return nil
+	}, nil
+}
+
+func emailRule(string) (TagCheck, error) {
+	return func(value reflect.Value) error {
+		if value.Kind() != reflect.String {
+			return nil
+		}
+
+		addr, err := mail.ParseAddress(value.String())
+		if err != nil || addr.Address != value.String() {
+			return errors.New("must be a valid email address")
+		}
+		return nil
+	}, nil
+}
+
+func oneOfRule(param string) (TagCheck, error) {
+	options := strings.Fields(param)
+	if len(options) == 0 {
+		return nil, errors.New("no options")
+	}
+
+	return func(value reflect.Value) error {
+		if !slices.Contains(options, fmt.Sprintf("%v", value.Interface())) {
+			return fmt.Errorf("must be one of [%s]", strings.Join(options, " "))
+		}
+		return nil
+	}, nil
+}
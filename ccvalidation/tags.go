@@ -0,0 +1,301 @@
+package ccvalidation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// TagName is the struct tag read by FromTags
+	TagName = "validate"
+
+	requiredTagRule  = "required"
+	omitEmptyTagRule = "omitempty"
+)
+
+// TagCheck checks a field value, returning an error describing the failure.
+// The error message is relative to the field, e.g. "must start with SKU-", and
+// becomes the message of a FieldError coded with the rule name. A returned
+// FieldError is kept, with its path prefixed by the field path
+type TagCheck func(value reflect.Value) error
+
+// TagRule builds the check of a tag rule from its parameter, e.g. "3" for min=3.
+// It returns an error when the parameter is not valid
+type TagRule func(param string) (TagCheck, error)
+
+var (
+	tagRulesMu sync.RWMutex
+	tagRules   = map[string]TagRule{
+		requiredTagRule: requiredRule,
+		"min":           minRule,
+		"max":           maxRule,
+		"len":           lenRule,
+		"email":         emailRule,
+		"oneof":         oneOfRule,
+	}
+)
+
+// RegisterTagRule registers a tag rule by name so it can be used in the
+// validate tags, e.g. RegisterTagRule("iban", ibanRule) for `validate:"iban"`.
+// Registering an existing name replaces the rule
+func RegisterTagRule(name string, rule TagRule) {
+	tagRulesMu.Lock()
+	defer tagRulesMu.Unlock()
+
+	tagRules[name] = rule
+}
+
+func getTagRule(name string) (TagRule, bool) {
+	tagRulesMu.RLock()
+	defer tagRulesMu.RUnlock()
+
+	rule, ok := tagRules[name]
+	return rule, ok
+}
+
+// FromTags creates a validator from the validate tags of the T struct fields:
+//
+//	type Order struct {
+//		ID     string  `json:"id" validate:"required,len=36"`
+//		Email  string  `json:"email" validate:"omitempty,email"`
+//		Status string  `json:"status" validate:"oneof=open closed"`
+//		Items  []Item  `json:"items" validate:"min=1,max=50"`
+//	}
+//
+// Nested structs, pointers, slices and maps are validated recursively. Rules
+// are applied to the value pointed by pointer fields, nil pointers only fail
+// the required rule. Fields are named after their JSON name.
+// It panics when a tag uses an unknown rule or an invalid parameter, as tags
+// are fixed at compile time
+func FromTags[T any]() *validator[T] {
+	plan := newTagPlan(reflect.TypeFor[T]())
+
+	v := NewValidator[T]()
+	v.addStep(validationStep[T]{
+		action: func(ctx context.Context, req T) error {
+			result := Result{}
+			plan.validateNested(reflect.ValueOf(&req).Elem(), "", &result)
+			if result.IsFailure() {
+				return result
+			}
+			return nil
+		},
+		describe: func() []Constraint {
+			return plan.constraints(reflect.TypeFor[T](), "", map[reflect.Type]bool{})
+		},
+	})
+
+	return v
+}
+
+type tagPlan struct {
+	structs map[reflect.Type][]fieldPlan
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	required  bool
+	omitEmpty bool
+	checks    []tagCheck
+}
+
+type tagCheck struct {
+	code   string
+	params map[string]any
+	check  TagCheck
+}
+
+func newTagPlan(t reflect.Type) *tagPlan {
+	p := &tagPlan{structs: make(map[reflect.Type][]fieldPlan)}
+	p.addType(t)
+	return p
+}
+
+func (p *tagPlan) addType(t reflect.Type) {
+	t = baseType(t)
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if _, ok := p.structs[t]; ok {
+		return
+	}
+	p.structs[t] = nil
+
+	fields := make([]fieldPlan, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(TagName)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		fields = append(fields, newFieldPlan(t, i, tag))
+		p.addType(field.Type)
+	}
+	p.structs[t] = fields
+}
+
+func newFieldPlan(t reflect.Type, index int, tag string) fieldPlan {
+	field := t.Field(index)
+	fp := fieldPlan{
+		index: index,
+		name:  tagFieldName(field),
+	}
+
+	for _, item := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch name {
+		case "":
+			continue
+		case omitEmptyTagRule:
+			fp.omitEmpty = true
+			continue
+		case requiredTagRule:
+			fp.required = true
+		}
+
+		rule, ok := getTagRule(name)
+		if !ok {
+			panic(fmt.Sprintf("ccvalidation: unknown rule %q in %s.%s", name, t.Name(), field.Name))
+		}
+
+		check, err := rule(param)
+		if err != nil {
+			panic(fmt.Sprintf("ccvalidation: invalid rule %q in %s.%s: %v", item, t.Name(), field.Name, err))
+		}
+		tc := tagCheck{code: name, check: check}
+		if param != "" {
+			tc.params = map[string]any{name: param}
+		}
+		fp.checks = append(fp.checks, tc)
+	}
+
+	return fp
+}
+
+func tagFieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func (p *tagPlan) validateField(value reflect.Value, path string, fp fieldPlan, result *Result) {
+	if fp.omitEmpty && value.IsZero() {
+		return
+	}
+
+	// required only checks that a pointer or interface is not nil
+	dereferenced := false
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			if fp.required {
+				result.AddFieldError(path, requiredTagRule, "is required", nil)
+			}
+			return
+		}
+		value = value.Elem()
+		dereferenced = true
+	}
+
+	for _, tc := range fp.checks {
+		if tc.code == requiredTagRule && dereferenced {
+			continue
+		}
+
+		err := tc.check(value)
+		if err == nil {
+			continue
+		}
+
+		// the other rules of a missing field are not checked
+		if tc.code == requiredTagRule {
+			result.AddFieldError(path, tc.code, err.Error(), tc.params)
+			return
+		}
+
+		var fieldErr FieldError
+		if errors.As(err, &fieldErr) {
+			result.AddFailure(fieldErr.WithPathPrefix(path))
+			continue
+		}
+		result.AddFieldError(path, tc.code, err.Error(), tc.params)
+	}
+
+	p.validateNested(value, path, result)
+}
+
+func (p *tagPlan) validateNested(value reflect.Value, path string, result *Result) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			p.validateNested(value.Elem(), path, result)
+		}
+
+	case reflect.Struct:
+		for _, fp := range p.structs[value.Type()] {
+			p.validateField(value.Field(fp.index), JoinPath(path, fp.name), fp, result)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if _, ok := p.structs[baseType(value.Type())]; !ok {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			p.validateNested(value.Index(i), fmt.Sprintf("%s[%d]", path, i), result)
+		}
+
+	case reflect.Map:
+		if _, ok := p.structs[baseType(value.Type())]; !ok {
+			return
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			p.validateNested(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), result)
+		}
+	}
+}
+
+// constraints describes the checks of the fields of the type, and of its
+// nested types, as Constraints
+func (p *tagPlan) constraints(t reflect.Type, path string, visiting map[reflect.Type]bool) []Constraint {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return p.constraints(t.Elem(), path, visiting)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return p.constraints(t.Elem(), path+ElementPath, visiting)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var constraints []Constraint
+	for _, fp := range p.structs[t] {
+		fieldPath := JoinPath(path, fp.name)
+		for _, tc := range fp.checks {
+			constraints = append(constraints, Constraint{Path: fieldPath, Code: tc.code, Params: tc.params})
+		}
+		constraints = append(constraints, p.constraints(t.Field(fp.index).Type, fieldPath, visiting)...)
+	}
+	return constraints
+}
+
+// baseType returns the type of the values held by the pointer, slice, array and map types
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice ||
+		t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,121 @@
+package ccvalidation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTagsAddress struct {
+	Street  string `json:"street" validate:"required"`
+	Country string `json:"country" validate:"len=2"`
+}
+
+type testTagsItem struct {
+	SKU      string `json:"sku" validate:"required,sku"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type testTagsOrder struct {
+	ID       string           `json:"id" validate:"required,min=3,max=50"`
+	Email    string           `json:"email" validate:"omitempty,email"`
+	Status   string           `json:"status" validate:"oneof=open closed"`
+	Address  *testTagsAddress `json:"address" validate:"required"`
+	Items    []testTagsItem   `json:"items" validate:"min=1"`
+	Internal string           `validate:"-"`
+}
+
+func init() {
+	RegisterTagRule("sku", func(string) (TagCheck, error) {
+		return func(value reflect.Value) error {
+			if !strings.HasPrefix(value.String(), "SKU-") {
+				return errors.New("must start with SKU-")
+			}
+			return nil
+		}, nil
+	})
+}
+
+func Test_FromTags_InvalidStruct_ShouldReturnFieldFailures(t *testing.T) {
+	// Arrange
+	order := testTagsOrder{
+		ID:      "A1",
+		Email:   "not-an-email",
+		Status:  "pending",
+		Address: &testTagsAddress{Country: "ESP"},
+		Items:   []testTagsItem{{SKU: "SKU-1", Quantity: 1}, {SKU: "1", Quantity: 11}},
+	}
+
+	// Act
+	result := FromTags[testTagsOrder]().Validate(order)
+
+	assert.Equal(t, []string{
+		"id must have at least 3 characters",
+		"email must be a valid email address",
+		"status must be one of [open closed]",
+		"address.street is required",
+		"address.country must have exactly 2 characters",
+		"items[1].sku must start with SKU-",
+		"items[1].quantity must be less than or equal to 10",
+	}, result.GetErrorMessages())
+}
+
+func Test_FromTags_Required_ShouldStopFieldRulesAndOnlyCheckPointersAreNotNil(t *testing.T) {
+	// Arrange
+	order := testTagsOrder{
+		Status:  "open",
+		Address: &testTagsAddress{},
+		Items:   []testTagsItem{{SKU: "SKU-1", Quantity: 1}},
+	}
+
+	// Act
+	result := FromTags[testTagsOrder]().Validate(order)
+
+	assert.Equal(t, []string{
+		"id is required",
+		"address.street is required",
+		"address.country must have exactly 2 characters",
+	}, result.GetErrorMessages())
+}
+
+func Test_FromTags_ValidStruct_ShouldSucceed(t *testing.T) {
+	// Arrange
+	order := testTagsOrder{
+		ID:      "A123",
+		Status:  "open",
+		Address: &testTagsAddress{Street: "Main St", Country: "ES"},
+		Items:   []testTagsItem{{SKU: "SKU-1", Quantity: 1}},
+	}
+
+	// Act
+	result := FromTags[*testTagsOrder]().Validate(&order)
+
+	assert.True(t, result.IsSuccess())
+}
+
+func Test_FromTags_AddStep_ShouldComposeWithTagRules(t *testing.T) {
+	// Arrange
+	v := FromTags[testTagsOrder]()
+	v.AddStep(func(o testTagsOrder) error {
+		if o.Status == "closed" && len(o.Items) > 0 {
+			return errors.New("closed orders cannot have items")
+		}
+		return nil
+	})
+
+	// Act
+	result := v.Validate(testTagsOrder{ID: "A123", Status: "closed", Items: []testTagsItem{{SKU: "SKU-1", Quantity: 1}}})
+
+	assert.Equal(t, []string{"address is required", "closed orders cannot have items"}, result.GetErrorMessages())
+}
+
+func Test_FromTags_UnknownRule_ShouldPanic(t *testing.T) {
+	type invalid struct {
+		Name string `validate:"unknown"`
+	}
+
+	assert.Panics(t, func() { FromTags[invalid]() })
+}
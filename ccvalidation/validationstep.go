@@ -4,7 +4,28 @@ import "context"
 
 type validationStep[T any] struct {
 	breakOnFailure bool
+	independent    bool
 	action         func(ctx context.Context, req T) error
+	// describe returns the constraints checked by the step
+	describe func() []Constraint
+}
+
+// when makes the step run only when the predicate is true for the request,
+// its constraints are described as conditional
+func (v *validationStep[T]) when(predicate func(T) bool) {
+	action := v.action
+	v.action = func(ctx context.Context, req T) error {
+		if !predicate(req) {
+			return nil
+		}
+		return action(ctx, req)
+	}
+
+	if describe := v.describe; describe != nil {
+		v.describe = func() []Constraint {
+			return conditionalConstraints(describe())
+		}
+	}
 }
 
 func (v *validationStep[T]) BreakOnFailure() *validationStep[T] {
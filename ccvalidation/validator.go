@@ -9,7 +9,11 @@ type Validator[T any] interface {
 
 type validator[T any] struct {
 	breakOnFailure bool
+	parallel       bool
+	concurrency    int
 	steps          []validationStep[T]
+	// lastAdded is the index of the first step added by the last Add call
+	lastAdded int
 }
 
 func (v validator[T]) Validate(src T) Result {
@@ -24,28 +28,36 @@ func (v validator[T]) ValidateCtx(ctx context.Context, src T) Result {
 		return result
 	}
 
+	if v.parallel {
+		return v.validateParallel(ctx, src)
+	}
+
 	for _, step := range v.steps {
 		err := step.action(ctx, src)
-		if err != nil {
-			if res, ok := err.(Result); ok {
-				if res.IsSuccess() {
-					continue
-				}
-				for _, v := range res.GetErrors() {
-					result.AddFailure(v)
-				}
-			} else {
-				result.AddFailure(err)
-			}
-			if step.breakOnFailure || v.breakOnFailure {
-				return result
-			}
+		if addStepError(&result, err) && (step.breakOnFailure || v.breakOnFailure) {
+			return result
 		}
 	}
 
 	return result
 }
 
+// addStepError adds the failures, warnings and infos of the error returned by
+// a step to the result, returning true when any failure was added
+func addStepError(result *Result, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if res, ok := err.(Result); ok {
+		result.Merge(res)
+		return res.IsFailure()
+	}
+
+	result.AddFailure(err)
+	return toFieldError(err).GetSeverity() == SeverityError
+}
+
 func NewValidator[T any]() *validator[T] {
 	return &validator[T]{
 		breakOnFailure: false,
@@ -62,17 +74,31 @@ func (v *validator[T]) BreakOnFailure() *validator[T] {
 	return v
 }
 
+// Parallel runs the consecutive independent steps concurrently, at most
+// concurrency at a time (no limit when concurrency is not positive). The other
+// steps run alone, in order, after the previous steps have finished
+func (v *validator[T]) Parallel(concurrency int) *validator[T] {
+	v.parallel = true
+	v.concurrency = concurrency
+	return v
+}
+
 func (v *validator[T]) AddStep(steps ...func(req T) error) {
 	if steps == nil {
 		steps = []func(req T) error{func(T) error { return nil }}
 	}
 
+	added := make([]validationStep[T], 0, len(steps))
 	for _, step := range steps {
 		ctxFunc := func(ctx context.Context, req T) error {
 			return step(req)
 		}
-		v.AddStepCtx(ctxFunc)
+		added = append(added, validationStep[T]{
+			action:   ctxFunc,
+			describe: describeCustomStep(step),
+		})
 	}
+	v.addStep(added...)
 }
 
 func (v *validator[T]) AddStepCtx(steps ...func(ctx context.Context, req T) error) {
@@ -84,18 +110,47 @@ func (v *validator[T]) AddStepCtx(steps ...func(ctx context.Context, req T) erro
 		}
 	}
 
+	added := make([]validationStep[T], 0, len(steps))
 	for _, step := range steps {
-		validationStep := validationStep[T]{
+		added = append(added, validationStep[T]{
 			breakOnFailure: false,
 			action:         step,
-		}
-		v.steps = append(v.steps, validationStep)
+			describe:       describeCustomStep(step),
+		})
+	}
+	v.addStep(added...)
+}
+
+// AddIndependentStepCtx adds steps that do not depend on the other steps, e.g.
+// database or downstream existence checks. In Parallel mode consecutive
+// independent steps run concurrently, otherwise they run like AddStepCtx steps
+func (v *validator[T]) AddIndependentStepCtx(steps ...func(ctx context.Context, req T) error) {
+	added := make([]validationStep[T], 0, len(steps))
+	for _, step := range steps {
+		added = append(added, validationStep[T]{
+			independent: true,
+			action:      step,
+			describe:    describeCustomStep(step),
+		})
+	}
+	v.addStep(added...)
+}
+
+// AddRules adds steps describing the constraints they check, e.g. rules.Field
+// steps, so they can be exported with Describe
+func (v *validator[T]) AddRules(steps ...RuleStep[T]) {
+	added := make([]validationStep[T], 0, len(steps))
+	for _, step := range steps {
+		added = append(added, validationStep[T]{
+			action:   step.Check,
+			describe: step.Constraints,
+		})
 	}
+	v.addStep(added...)
 }
 
 func (v *validator[T]) AddValidator(vldtr Validator[T]) {
-	if v.breakOnFailure {
-		val, _ := vldtr.(*validator[T])
+	if val, ok := vldtr.(*validator[T]); ok && v.breakOnFailure {
 		val.breakOnFailure = true
 		val.steps = append(val.steps, v.steps...)
 		vldtr = val
@@ -104,8 +159,47 @@ func (v *validator[T]) AddValidator(vldtr Validator[T]) {
 	ctxFunc := func(ctx context.Context, req T) error {
 		return vldtr.ValidateCtx(ctx, req)
 	}
-	v.AddStepCtx(ctxFunc)
+	v.addStep(validationStep[T]{
+		action:   ctxFunc,
+		describe: describeValidator(vldtr, ""),
+	})
+
+}
+
+// AddValidatorAt adds a validator whose FieldError paths are prefixed with the
+// path, e.g. "address" turns the "street" failures into "address.street" ones
+func (v *validator[T]) AddValidatorAt(path string, vldtr Validator[T]) {
+	v.addStep(validationStep[T]{
+		action: func(ctx context.Context, req T) error {
+			return vldtr.ValidateCtx(ctx, req).WithPathPrefix(path)
+		},
+		describe: describeValidator(vldtr, path),
+	})
+}
+
+// When makes the steps added by the last Add call, or by Field, ForEach and
+// ForMap, run only when the predicate is true for the request:
+//
+//	v.AddStep(validateVATNumber)
+//	v.When(func(c Customer) bool { return c.IsEU() && c.Type == Business })
+func (v *validator[T]) When(predicate func(T) bool) *validator[T] {
+	for i := v.lastAdded; i < len(v.steps); i++ {
+		v.steps[i].when(predicate)
+	}
+	return v
+}
+
+// Unless makes the steps added by the last Add call run only when the
+// predicate is false for the request, see When
+func (v *validator[T]) Unless(predicate func(T) bool) *validator[T] {
+	return v.When(func(req T) bool {
+		return !predicate(req)
+	})
+}
 
+func (v *validator[T]) addStep(steps ...validationStep[T]) {
+	v.lastAdded = len(v.steps)
+	v.steps = append(v.steps, steps...)
 }
 
 func (v *validator[T]) hasValidationItems() bool {